@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/36625090/solana-go/types"
+)
+
+// legacyBlockhashValidity is the number of blocks a blockhash is valid for on nodes without getLatestBlockhash
+const legacyBlockhashValidity = 150
+
+// nodeVersionRetryInterval is how long a failed version probe is trusted as "not legacy" before it is repeated
+const nodeVersionRetryInterval = time.Minute
+
+// nodeVersion caches whether the node predates getLatestBlockhash, isBlockhashValid and getFeeForMessage.
+// The zero value is ready to use.
+type nodeVersion struct {
+	mu      sync.Mutex
+	checked bool
+	legacy  bool
+	retryAt time.Time
+}
+
+// isLegacyNode reports the node is older than solana-core v1.9. The node is assumed to be new if the version is unknown.
+func (c *Client) isLegacyNode(ctx context.Context) bool {
+	v := &c.nodeVersion
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.checked || time.Now().Before(v.retryAt) {
+		return v.legacy
+	}
+
+	res, err := c.RpcClient.GetVersion(ctx)
+	if err != nil {
+		v.retryAt = time.Now().Add(nodeVersionRetryInterval)
+		return false
+	}
+	v.checked, v.legacy = true, versionBefore(res.SolanaCore, 1, 9)
+	return v.legacy
+}
+
+func versionBefore(version string, major, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return gotMajor < major || (gotMajor == major && gotMinor < minor)
+}
+
+// GetLatestBlockhash returns the latest blockhash and the last block height at which it is valid.
+// On nodes older than v1.9 it falls back to getRecentBlockhash and estimates the last valid block height.
+func (c *Client) GetLatestBlockhash(ctx context.Context) (rpc.GetLatestBlockhashResultValue, error) {
+	return c.GetLatestBlockhashWithConfig(ctx, rpc.GetLatestBlockhashConfig{})
+}
+
+// GetLatestBlockhashWithConfig returns the latest blockhash and the last block height at which it is valid
+func (c *Client) GetLatestBlockhashWithConfig(ctx context.Context, cfg rpc.GetLatestBlockhashConfig) (rpc.GetLatestBlockhashResultValue, error) {
+	if c.isLegacyNode(ctx) {
+		res, err := c.RpcClient.GetRecentBlockhashWithConfig(ctx, rpc.GetRecentBlockhashConfig{Commitment: cfg.Commitment})
+		err = checkRpcResult(res.GeneralResponse, err)
+		if err != nil {
+			return rpc.GetLatestBlockhashResultValue{}, err
+		}
+		blockHeight, err := c.RpcClient.GetBlockHeight(ctx, rpc.GetBlockHeightConfig{Commitment: cfg.Commitment})
+		if err != nil {
+			return rpc.GetLatestBlockhashResultValue{}, fmt.Errorf("failed to get block height, err: %w", err)
+		}
+		return rpc.GetLatestBlockhashResultValue{
+			Blockhash:            res.Result.Value.Blockhash,
+			LastValidBlockHeight: blockHeight + legacyBlockhashValidity,
+		}, nil
+	}
+
+	res, err := c.RpcClient.GetLatestBlockhashWithConfig(ctx, cfg)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return rpc.GetLatestBlockhashResultValue{}, err
+	}
+	return res.Result.Value, nil
+}
+
+// blockhash returns the blockhash for a new transaction
+func (c *Client) blockhash(ctx context.Context) (rpc.GetLatestBlockhashResultValue, error) {
+	if c.blockhashProvider != nil {
+		return c.blockhashProvider.LatestBlockhash(ctx)
+	}
+	return c.GetLatestBlockhash(ctx)
+}
+
+// IsBlockhashValid reports the blockhash can still be used by new transactions
+func (c *Client) IsBlockhashValid(ctx context.Context, blockhash string) (bool, error) {
+	if c.isLegacyNode(ctx) {
+		res, err := c.RpcClient.GetFeeCalculatorForBlockhash(ctx, blockhash)
+		err = checkRpcResult(res.GeneralResponse, err)
+		if err != nil {
+			return false, err
+		}
+		return res.Result.Value != nil, nil
+	}
+
+	res, err := c.RpcClient.IsBlockhashValid(ctx, blockhash)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return false, err
+	}
+	return res.Result.Value, nil
+}
+
+// GetFeeForMessage returns the fee of the message in lamports, it returns an error wrapping ErrBlockhashExpired
+// if the blockhash of the message expired
+func (c *Client) GetFeeForMessage(ctx context.Context, message types.Message) (uint64, error) {
+	if c.isLegacyNode(ctx) {
+		res, err := c.RpcClient.GetFeeCalculatorForBlockhash(ctx, message.RecentBlockHash)
+		err = checkRpcResult(res.GeneralResponse, err)
+		if err != nil {
+			return 0, err
+		}
+		if res.Result.Value == nil {
+			return 0, fmt.Errorf("%w, blockhash: %v", ErrBlockhashExpired, message.RecentBlockHash)
+		}
+		return res.Result.Value.FeeCalculator.LamportsPerSignature * uint64(message.Header.NumRequireSignatures), nil
+	}
+
+	rawMessage, err := message.Serialize()
+	if err != nil {
+		return 0, fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	res, err := c.RpcClient.GetFeeForMessage(ctx, base64.StdEncoding.EncodeToString(rawMessage))
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return 0, err
+	}
+	if res.Result.Value == nil {
+		return 0, fmt.Errorf("%w, blockhash: %v", ErrBlockhashExpired, message.RecentBlockHash)
+	}
+	return *res.Result.Value, nil
+}
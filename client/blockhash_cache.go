@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/36625090/solana-go/client/rpc"
+)
+
+const (
+	defaultBlockhashRefreshInterval = 5 * time.Second
+	defaultBlockhashMaxAge          = 30 * time.Second
+	defaultBlockhashFetchTimeout    = 10 * time.Second
+)
+
+// ErrBlockhashCacheClosed is returned by a closed BlockhashCache
+var ErrBlockhashCacheClosed = errors.New("blockhash cache closed")
+
+// BlockhashProvider provides the blockhash of new transactions
+type BlockhashProvider interface {
+	LatestBlockhash(ctx context.Context) (rpc.GetLatestBlockhashResultValue, error)
+}
+
+// BlockhashCacheConfig is an option config for NewBlockhashCache
+type BlockhashCacheConfig struct {
+	Commitment rpc.Commitment
+	// RefreshInterval is how often the blockhash is refreshed in the background. default: 5s
+	RefreshInterval time.Duration
+	// MaxAge is how long a blockhash is served after it was fetched, a staler one is fetched again
+	// before it is returned, e.g. the background refresh keeps failing. default: 30s
+	MaxAge time.Duration
+}
+
+// BlockhashCache is a BlockhashProvider which shares one blockhash across all callers and refreshes it in
+// the background. It is safe for concurrent use, call Close to stop refreshing.
+type BlockhashCache struct {
+	client *Client
+	cfg    BlockhashCacheConfig
+
+	fetchMu sync.Mutex
+
+	mu        sync.RWMutex
+	value     rpc.GetLatestBlockhashResultValue
+	fetchedAt time.Time
+	lastErr   error
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBlockhashCache creates a cache which fetches blockhashes by c and starts the background refresh
+func NewBlockhashCache(c *Client, cfg BlockhashCacheConfig) *BlockhashCache {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaultBlockhashRefreshInterval
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = defaultBlockhashMaxAge
+	}
+	b := &BlockhashCache{
+		client: c,
+		cfg:    cfg,
+		closed: make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// LatestBlockhash returns the cached blockhash, it is fetched first if there is none or it is older than MaxAge
+func (b *BlockhashCache) LatestBlockhash(ctx context.Context) (rpc.GetLatestBlockhashResultValue, error) {
+	select {
+	case <-b.closed:
+		return rpc.GetLatestBlockhashResultValue{}, ErrBlockhashCacheClosed
+	default:
+	}
+	if value, ok := b.cached(); ok {
+		return value, nil
+	}
+
+	b.fetchMu.Lock()
+	defer b.fetchMu.Unlock()
+	// fetched by another caller meanwhile
+	if value, ok := b.cached(); ok {
+		return value, nil
+	}
+	return b.fetch(ctx)
+}
+
+// LastValidBlockHeight returns the last block height at which the cached blockhash is valid, 0 if there is none
+func (b *BlockhashCache) LastValidBlockHeight() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.value.LastValidBlockHeight
+}
+
+// LastError returns the error of the latest failed refresh, nil if the latest refresh succeeded
+func (b *BlockhashCache) LastError() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lastErr
+}
+
+// Invalidate drops the cached blockhash, e.g. after a transaction was rejected with BlockhashNotFound
+func (b *BlockhashCache) Invalidate() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.value = rpc.GetLatestBlockhashResultValue{}
+	b.fetchedAt = time.Time{}
+}
+
+// Close stops the background refresh
+func (b *BlockhashCache) Close() {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+}
+
+func (b *BlockhashCache) cached() (rpc.GetLatestBlockhashResultValue, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.value.Blockhash == "" || time.Since(b.fetchedAt) > b.cfg.MaxAge {
+		return rpc.GetLatestBlockhashResultValue{}, false
+	}
+	return b.value, true
+}
+
+func (b *BlockhashCache) fetch(ctx context.Context) (rpc.GetLatestBlockhashResultValue, error) {
+	value, err := b.client.GetLatestBlockhashWithConfig(ctx, rpc.GetLatestBlockhashConfig{Commitment: b.cfg.Commitment})
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastErr = err
+	if err != nil {
+		return rpc.GetLatestBlockhashResultValue{}, err
+	}
+	b.value = value
+	b.fetchedAt = time.Now()
+	return value, nil
+}
+
+func (b *BlockhashCache) run() {
+	ticker := time.NewTicker(b.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		b.refresh()
+		select {
+		case <-ticker.C:
+		case <-b.closed:
+			return
+		}
+	}
+}
+
+func (b *BlockhashCache) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultBlockhashFetchTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-b.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	b.fetchMu.Lock()
+	defer b.fetchMu.Unlock()
+	// a caller has just fetched one
+	b.mu.RLock()
+	fresh := b.value.Blockhash != "" && time.Since(b.fetchedAt) < b.cfg.RefreshInterval/2
+	b.mu.RUnlock()
+	if fresh {
+		return
+	}
+	b.fetch(ctx)
+}
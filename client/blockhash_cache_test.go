@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockhashCache(t *testing.T) {
+	var fetched int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&fetched, 1) == 1 {
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":{"feature-set":1,"solana-core":"1.14.17"},"id":1}`))
+			return
+		}
+		rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":2792},"value":{"blockhash":"EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N","lastValidBlockHeight":3090}},"id":1}`))
+	}))
+	defer server.Close()
+	c := NewClient(server.URL)
+	// warm up the version check, every following request is getLatestBlockhash
+	_, err := c.GetLatestBlockhash(context.Background())
+	assert.Nil(t, err)
+	atomic.StoreInt32(&fetched, 1)
+
+	cache := NewBlockhashCache(c, BlockhashCacheConfig{RefreshInterval: time.Hour})
+	defer cache.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := cache.LatestBlockhash(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, rpc.GetLatestBlockhashResultValue{
+				Blockhash:            "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N",
+				LastValidBlockHeight: 3090,
+			}, res)
+		}()
+	}
+	wg.Wait()
+	// the initial refresh and the callers share one request
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetched))
+	assert.Equal(t, uint64(3090), cache.LastValidBlockHeight())
+	assert.Nil(t, cache.LastError())
+
+	cache.Invalidate()
+	_, err = cache.LatestBlockhash(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fetched))
+
+	cache.Close()
+	_, err = cache.LatestBlockhash(context.Background())
+	assert.Equal(t, ErrBlockhashCacheClosed, err)
+}
+
+type staticBlockhashProvider rpc.GetLatestBlockhashResultValue
+
+func (p staticBlockhashProvider) LatestBlockhash(ctx context.Context) (rpc.GetLatestBlockhashResultValue, error) {
+	return rpc.GetLatestBlockhashResultValue(p), nil
+}
+
+func TestClientBlockhashProvider(t *testing.T) {
+	c := NewClient("http://127.0.0.1:0")
+	c.SetBlockhashProvider(staticBlockhashProvider{Blockhash: "867JxboSVrJLWQNZfF2odbP1QVVsd3DHYxbhsRX85Tsj", LastValidBlockHeight: 150})
+
+	res, err := c.blockhash(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "867JxboSVrJLWQNZfF2odbP1QVVsd3DHYxbhsRX85Tsj", res.Blockhash)
+}
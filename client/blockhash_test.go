@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newVersionedNode(t *testing.T, version string, calls map[string]int) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		var r struct {
+			Method string `json:"method"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &r))
+		mu.Lock()
+		calls[r.Method]++
+		mu.Unlock()
+
+		switch r.Method {
+		case "getVersion":
+			if version == "" {
+				rw.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":{"feature-set":1,"solana-core":"%v"},"id":1}`, version)))
+		case "getLatestBlockhash":
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":2792},"value":{"blockhash":"EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N","lastValidBlockHeight":3090}},"id":1}`))
+		case "getRecentBlockhash":
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":2792},"value":{"blockhash":"867JxboSVrJLWQNZfF2odbP1QVVsd3DHYxbhsRX85Tsj","feeCalculator":{"lamportsPerSignature":5000}}},"id":1}`))
+		case "getBlockHeight":
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":1000,"id":1}`))
+		case "isBlockhashValid":
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":2792},"value":true},"id":1}`))
+		case "getFeeForMessage":
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":2792},"value":null},"id":1}`))
+		case "getFeeCalculatorForBlockhash":
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":2792},"value":{"feeCalculator":{"lamportsPerSignature":5000}}},"id":1}`))
+		default:
+			rw.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`))
+		}
+	}))
+}
+
+func TestGetLatestBlockhash(t *testing.T) {
+	calls := map[string]int{}
+	server := newVersionedNode(t, "1.14.17", calls)
+	defer server.Close()
+	c := NewClient(server.URL)
+
+	for i := 0; i < 2; i++ {
+		res, err := c.GetLatestBlockhash(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, rpc.GetLatestBlockhashResultValue{
+			Blockhash:            "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N",
+			LastValidBlockHeight: 3090,
+		}, res)
+	}
+	// the version is checked once
+	assert.Equal(t, 1, calls["getVersion"])
+
+	valid, err := c.IsBlockhashValid(context.Background(), "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	_, err = c.GetFeeForMessage(context.Background(), types.NewMessage(feePayer, nil, "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N"))
+	assert.True(t, errors.Is(err, ErrBlockhashExpired))
+}
+
+func TestGetLatestBlockhashLegacyNode(t *testing.T) {
+	calls := map[string]int{}
+	server := newVersionedNode(t, "1.8.16", calls)
+	defer server.Close()
+	c := NewClient(server.URL)
+
+	res, err := c.GetLatestBlockhash(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, rpc.GetLatestBlockhashResultValue{
+		Blockhash:            "867JxboSVrJLWQNZfF2odbP1QVVsd3DHYxbhsRX85Tsj",
+		LastValidBlockHeight: 1150,
+	}, res)
+	assert.Equal(t, 0, calls["getLatestBlockhash"])
+
+	valid, err := c.IsBlockhashValid(context.Background(), "867JxboSVrJLWQNZfF2odbP1QVVsd3DHYxbhsRX85Tsj")
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	fee, err := c.GetFeeForMessage(context.Background(), types.NewMessage(feePayer, nil, "867JxboSVrJLWQNZfF2odbP1QVVsd3DHYxbhsRX85Tsj"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5000), fee)
+	assert.Equal(t, 1, calls["getVersion"])
+}
+
+func TestGetLatestBlockhashVersionUnknown(t *testing.T) {
+	calls := map[string]int{}
+	server := newVersionedNode(t, "", calls)
+	defer server.Close()
+	// a client without NewClient caches the version as well
+	c := &Client{RpcClient: rpc.NewRpcClient(server.URL)}
+
+	for i := 0; i < 2; i++ {
+		res, err := c.GetLatestBlockhash(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N", res.Blockhash)
+	}
+	// the failed probe is not repeated right away
+	assert.Equal(t, 1, calls["getVersion"])
+}
@@ -3,19 +3,36 @@ package client
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
+	"sync"
+
 	"github.com/36625090/solana-go/client/rpc"
 	"github.com/36625090/solana-go/common"
 	"github.com/36625090/solana-go/types"
+	"github.com/klauspost/compress/zstd"
 )
 
 type Client struct {
 	rpc.RpcClient
+	nodeVersion       nodeVersion
+	blockhashProvider BlockhashProvider
+}
+
+func NewClient(endpoint string, opts ...rpc.Option) *Client {
+	return NewClientWithRpcClient(rpc.NewRpcClient(endpoint, opts...))
+}
+
+// NewClientWithRpcClient wraps a prepared rpc client, e.g. the one of rpc.Failover
+func NewClientWithRpcClient(rpcClient rpc.RpcClient) *Client {
+	return &Client{
+		RpcClient: rpcClient,
+	}
 }
 
-func NewClient(endpoint string) *Client {
-	return &Client{rpc.NewRpcClient(endpoint)}
+// SetBlockhashProvider makes the transaction builders of the client, e.g. SendTransaction, take blockhashes from p
+// instead of fetching a new one every time. It should be called before the client is used.
+func (c *Client) SetBlockhashProvider(p BlockhashProvider) {
+	c.blockhashProvider = p
 }
 
 // GetBalance fetch users lamports(SOL) balance
@@ -58,29 +75,64 @@ func (c *Client) GetAccountInfo(ctx context.Context, base58Addr string) (Account
 	if res.Result.Value == (rpc.GetAccountInfoResultValue{}) {
 		return AccountInfo{}, nil
 	}
+	return decodeAccountInfo(res.Result.Value)
+}
 
-	data, ok := res.Result.Value.Data.([]interface{})
-	if !ok {
+var (
+	zstdDecoder     *zstd.Decoder
+	zstdDecoderErr  error
+	zstdDecoderOnce sync.Once
+)
+
+// decodeAccountInfo decodes an account fetched in base64 or base64+zstd encoding
+func decodeAccountInfo(v rpc.GetAccountInfoResultValue) (AccountInfo, error) {
+	data, ok := v.Data.([]interface{})
+	if !ok || len(data) != 2 {
 		return AccountInfo{}, fmt.Errorf("failed to cast raw response to []interface{}")
 	}
-	if data[1] != string(rpc.GetAccountInfoConfigEncodingBase64) {
-		return AccountInfo{}, fmt.Errorf("encoding mistmatch")
+	encoded, ok := data[0].(string)
+	if !ok {
+		return AccountInfo{}, fmt.Errorf("failed to cast raw data to string")
 	}
 
-	rawData, err := base64.StdEncoding.DecodeString(data[0].(string))
-	if err != nil {
-		return AccountInfo{}, fmt.Errorf("failed to base64 decode data")
+	var rawData []byte
+	var err error
+	switch data[1] {
+	case string(rpc.GetAccountInfoConfigEncodingBase64):
+		rawData, err = base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return AccountInfo{}, fmt.Errorf("failed to base64 decode data")
+		}
+	case string(rpc.GetAccountInfoConfigEncodingBase64Zstd):
+		compressed, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return AccountInfo{}, fmt.Errorf("failed to base64 decode data")
+		}
+		zstdDecoderOnce.Do(func() {
+			zstdDecoder, zstdDecoderErr = zstd.NewReader(nil)
+		})
+		if zstdDecoderErr != nil {
+			return AccountInfo{}, fmt.Errorf("failed to create zstd decoder, err: %v", zstdDecoderErr)
+		}
+		rawData, err = zstdDecoder.DecodeAll(compressed, nil)
+		if err != nil {
+			return AccountInfo{}, fmt.Errorf("failed to zstd decode data, err: %v", err)
+		}
+	default:
+		return AccountInfo{}, fmt.Errorf("encoding mistmatch")
 	}
+
 	return AccountInfo{
-		Lamports:  res.Result.Value.Lamports,
-		Owner:     res.Result.Value.Owner,
-		Excutable: res.Result.Value.Excutable,
-		RentEpoch: res.Result.Value.RentEpoch,
+		Lamports:  v.Lamports,
+		Owner:     v.Owner,
+		Excutable: v.Excutable,
+		RentEpoch: v.RentEpoch,
 		Data:      rawData,
 	}, nil
 }
 
 // GetRecentBlockhash return recent blockhash information
+// DEPRECATED: getRecentBlockhash is removed from solana-core v1.10, please use GetLatestBlockhash instead
 func (c *Client) GetRecentBlockhash(ctx context.Context) (rpc.GetRecentBlockHashResultValue, error) {
 	res, err := c.RpcClient.GetRecentBlockhash(ctx)
 	err = checkRpcResult(res.GeneralResponse, err)
@@ -114,15 +166,30 @@ type SendTransactionParam struct {
 
 // SendTransaction is a quick way to send tx
 func (c *Client) SendTransaction(ctx context.Context, param SendTransactionParam) (string, error) {
-	recentBlockhashRes, err := c.GetRecentBlockhash(ctx)
+	return c.SendTransactionWithSigners(ctx, SendTransactionWithSignersParam{
+		Instructions: param.Instructions,
+		Signers:      types.AccountSigners(param.Signers),
+		FeePayer:     param.FeePayer,
+	})
+}
+
+type SendTransactionWithSignersParam struct {
+	Instructions []types.Instruction
+	Signers      []types.Signer
+	FeePayer     common.PublicKey
+}
+
+// SendTransactionWithSigners is SendTransaction with signers which may keep their private keys elsewhere
+func (c *Client) SendTransactionWithSigners(ctx context.Context, param SendTransactionWithSignersParam) (string, error) {
+	latestBlockhash, err := c.blockhash(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get recent blockhash, err: %v", err)
+		return "", fmt.Errorf("failed to get latest blockhash, err: %v", err)
 	}
-	rawTx, err := types.CreateRawTransaction(types.CreateRawTransactionParam{
+	rawTx, err := types.CreateRawTransactionWithSigners(ctx, types.CreateRawTransactionWithSignersParam{
 		Instructions:    param.Instructions,
 		Signers:         param.Signers,
 		FeePayer:        param.FeePayer,
-		RecentBlockHash: recentBlockhashRes.Blockhash,
+		RecentBlockHash: latestBlockhash.Blockhash,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to build tx, err: %v", err)
@@ -184,11 +251,7 @@ func checkRpcResult(res rpc.GeneralResponse, err error) error {
 		return err
 	}
 	if res.Error != nil {
-		errRes, err := json.Marshal(res.Error)
-		if err != nil {
-			return fmt.Errorf("rpc response error: %v", res.Error)
-		}
-		return fmt.Errorf("rpc response error: %v", string(errRes))
+		return res.Error.RpcError()
 	}
 	return nil
 }
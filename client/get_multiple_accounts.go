@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/36625090/solana-go/client/rpc"
+)
+
+const defaultGetMultipleAccountsConcurrency = 4
+
+// GetMultipleAccountsConfig is an option config for Client.GetMultipleAccountsWithCfg
+type GetMultipleAccountsConfig struct {
+	Commitment rpc.Commitment
+	// Encoding is base64 or base64+zstd. default: base64
+	Encoding  rpc.GetAccountInfoConfigEncoding
+	DataSlice *rpc.GetAccountInfoConfigDataSlice
+	// ChunkSize is the number of addresses per request. default and max: rpc.GetMultipleAccountsLimit
+	ChunkSize int
+	// Concurrency is the max number of requests in flight. default: 4
+	Concurrency int
+}
+
+// GetMultipleAccounts fetches any number of accounts. The result is in the order of the addresses
+// and the entry of an account which doesn't exist is nil.
+func (c *Client) GetMultipleAccounts(ctx context.Context, base58Addrs []string) ([]*AccountInfo, error) {
+	return c.GetMultipleAccountsWithCfg(ctx, base58Addrs, GetMultipleAccountsConfig{})
+}
+
+// GetMultipleAccountsWithCfg fetches any number of accounts, the addresses are split into chunks which are
+// fetched concurrently. It fails if any chunk fails.
+func (c *Client) GetMultipleAccountsWithCfg(ctx context.Context, base58Addrs []string, cfg GetMultipleAccountsConfig) ([]*AccountInfo, error) {
+	if cfg.Encoding == "" {
+		cfg.Encoding = rpc.GetAccountInfoConfigEncodingBase64
+	}
+	if cfg.Encoding != rpc.GetAccountInfoConfigEncodingBase64 && cfg.Encoding != rpc.GetAccountInfoConfigEncodingBase64Zstd {
+		return nil, fmt.Errorf("unsupported encoding: %v", cfg.Encoding)
+	}
+	if cfg.ChunkSize <= 0 || cfg.ChunkSize > rpc.GetMultipleAccountsLimit {
+		cfg.ChunkSize = rpc.GetMultipleAccountsLimit
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultGetMultipleAccountsConcurrency
+	}
+	rpcCfg := rpc.GetMultipleAccountsConfig{
+		Commitment: cfg.Commitment,
+		Encoding:   cfg.Encoding,
+		DataSlice:  cfg.DataSlice,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	accounts := make([]*AccountInfo, len(base58Addrs))
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for start := 0; start < len(base58Addrs); start += cfg.ChunkSize {
+		end := start + cfg.ChunkSize
+		if end > len(base58Addrs) {
+			end = len(base58Addrs)
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := c.getMultipleAccountsChunk(ctx, base58Addrs[start:end], rpcCfg, accounts[start:end])
+			if err != nil {
+				fail(fmt.Errorf("failed to get accounts %v-%v, err: %w", start, end-1, err))
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (c *Client) getMultipleAccountsChunk(ctx context.Context, base58Addrs []string, cfg rpc.GetMultipleAccountsConfig, accounts []*AccountInfo) error {
+	res, err := c.RpcClient.GetMultipleAccountsWithCfg(ctx, base58Addrs, cfg)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return err
+	}
+	if len(res.Result.Value) != len(base58Addrs) {
+		return fmt.Errorf("expected %v accounts, got %v", len(base58Addrs), len(res.Result.Value))
+	}
+	for i, v := range res.Result.Value {
+		if v == nil {
+			continue
+		}
+		info, err := decodeAccountInfo(*v)
+		if err != nil {
+			return fmt.Errorf("failed to decode account %v, err: %w", base58Addrs[i], err)
+		}
+		accounts[i] = &info
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMultipleAccounts(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		body, _ := ioutil.ReadAll(req.Body)
+		var r struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &r))
+		var addrs []string
+		assert.Nil(t, json.Unmarshal(r.Params[0], &addrs))
+		assert.LessOrEqual(t, len(addrs), 2)
+
+		values := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			if strings.HasPrefix(addr, "missing") {
+				values = append(values, "null")
+				continue
+			}
+			values = append(values, fmt.Sprintf(`{"data":["%v","base64+zstd"],"executable":false,"lamports":%v,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":178}`,
+				"KLUv/QBYjQEAhAIBAAAABj5w2ZFXmNyj7tuRN89kxw/6+2LN04KBBSUL12sdbN4ACQEAAgAAGXXBEw==", len(addr)))
+		}
+		rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":[%v]},"id":1}`, strings.Join(values, ","))))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	addrs := []string{"a", "missing1", "abc", "abcd", "missing2"}
+	accounts, err := c.GetMultipleAccountsWithCfg(context.Background(), addrs, GetMultipleAccountsConfig{
+		Encoding:  rpc.GetAccountInfoConfigEncodingBase64Zstd,
+		ChunkSize: 2,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Len(t, accounts, len(addrs))
+	for i, addr := range addrs {
+		if strings.HasPrefix(addr, "missing") {
+			assert.Nil(t, accounts[i])
+			continue
+		}
+		assert.Equal(t, uint64(len(addr)), accounts[i].Lamports)
+		assert.Len(t, accounts[i].Data, 82)
+	}
+}
+
+func TestGetMultipleAccountsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Too many inputs provided; max 100"},"id":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	_, err := c.GetMultipleAccounts(context.Background(), []string{"a", "b"})
+	var rpcErr *rpc.RpcError
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, rpc.ErrorCodeInvalidParams, rpcErr.Code)
+}
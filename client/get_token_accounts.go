@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/program/tokenprog"
+)
+
+// GetTokenAccountsByOwner returns all token accounts of the owner keyed by their address
+func (c *Client) GetTokenAccountsByOwner(ctx context.Context, base58Addr string) (map[string]tokenprog.TokenAccount, error) {
+	return c.getTokenAccounts(c.RpcClient.GetTokenAccountsByOwnerWithCfg(
+		ctx,
+		base58Addr,
+		rpc.GetTokenAccountsFilter{ProgramId: common.TokenProgramID.ToBase58()},
+		rpc.GetTokenAccountsConfig{Encoding: rpc.GetTokenAccountsConfigEncodingBase64},
+	))
+}
+
+// GetTokenAccountsByOwnerAndMint returns the token accounts of the owner for the mint keyed by their address
+func (c *Client) GetTokenAccountsByOwnerAndMint(ctx context.Context, base58Addr, mintBase58Addr string) (map[string]tokenprog.TokenAccount, error) {
+	return c.getTokenAccounts(c.RpcClient.GetTokenAccountsByOwnerWithCfg(
+		ctx,
+		base58Addr,
+		rpc.GetTokenAccountsFilter{Mint: mintBase58Addr},
+		rpc.GetTokenAccountsConfig{Encoding: rpc.GetTokenAccountsConfigEncodingBase64},
+	))
+}
+
+// GetTokenAccountsByDelegate returns all token accounts approved to the delegate keyed by their address
+func (c *Client) GetTokenAccountsByDelegate(ctx context.Context, base58Addr string) (map[string]tokenprog.TokenAccount, error) {
+	return c.getTokenAccounts(c.RpcClient.GetTokenAccountsByDelegateWithCfg(
+		ctx,
+		base58Addr,
+		rpc.GetTokenAccountsFilter{ProgramId: common.TokenProgramID.ToBase58()},
+		rpc.GetTokenAccountsConfig{Encoding: rpc.GetTokenAccountsConfigEncodingBase64},
+	))
+}
+
+func (c *Client) getTokenAccounts(res rpc.GetTokenAccountsResponse, err error) (map[string]tokenprog.TokenAccount, error) {
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make(map[string]tokenprog.TokenAccount, len(res.Result.Value))
+	for _, v := range res.Result.Value {
+		info, err := decodeAccountInfo(rpc.GetAccountInfoResultValue{
+			Lamports:  v.Account.Lamports,
+			Owner:     v.Account.Owner,
+			Excutable: v.Account.Executable,
+			RentEpoch: v.Account.RentEpoch,
+			Data:      v.Account.Data,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode account %v, err: %w", v.Pubkey, err)
+		}
+		tokenAccount, err := tokenprog.TokenAccountFromData(info.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse token account %v, err: %w", v.Pubkey, err)
+		}
+		accounts[v.Pubkey] = *tokenAccount
+	}
+	return accounts, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/program/tokenprog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTokenAccountsByOwner(t *testing.T) {
+	mint := common.PublicKeyFromString("Gh9ZwEmdLJ8DscKNTkTqPbNwLNNBjuSzaG9Vp2KGtKJr")
+	owner := common.PublicKeyFromString("27kVX7JpPZ1bsrSckbR76mV6GeRqtrjoddubfg2zBpHZ")
+	data := make([]byte, tokenprog.TokenAccountSize)
+	copy(data[:32], mint.Bytes())
+	copy(data[32:64], owner.Bytes())
+	binary.LittleEndian.PutUint64(data[64:72], 100)
+	data[108] = byte(tokenprog.TokenAccountStateInitialized)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":[{"account":{"data":["%v","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":185},"pubkey":"AyHWro8zumyZN68Mfu53gdr5ctZHYgfVJCo3h2nRHtMt"}]},"id":1}`,
+			base64.StdEncoding.EncodeToString(data))))
+	}))
+	defer server.Close()
+
+	accounts, err := NewClient(server.URL).GetTokenAccountsByOwner(context.Background(), owner.ToBase58())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]tokenprog.TokenAccount{
+		"AyHWro8zumyZN68Mfu53gdr5ctZHYgfVJCo3h2nRHtMt": {
+			Mint:   mint,
+			Owner:  owner,
+			Amount: 100,
+			State:  tokenprog.TokenAccountStateInitialized,
+		},
+	}, accounts)
+}
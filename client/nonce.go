@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/program/sysprog"
+	"github.com/36625090/solana-go/types"
+)
+
+// ErrNonceAdvanced is returned if the nonce of a durable nonce transaction is advanced before the transaction is
+// confirmed, the transaction can't land anymore
+var ErrNonceAdvanced = errors.New("nonce advanced")
+
+// GetNonceAccount fetches and decodes an initialized nonce account
+func (c *Client) GetNonceAccount(ctx context.Context, base58Addr string) (sysprog.NonceAccount, error) {
+	return c.getNonceAccount(ctx, base58Addr, "")
+}
+
+func (c *Client) getNonceAccount(ctx context.Context, base58Addr string, commitment rpc.Commitment) (sysprog.NonceAccount, error) {
+	res, err := c.RpcClient.GetAccountInfoWithCfg(ctx, base58Addr, rpc.GetAccountInfoConfig{
+		Commitment: commitment,
+		Encoding:   rpc.GetAccountInfoConfigEncodingBase64,
+	})
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return sysprog.NonceAccount{}, err
+	}
+	if res.Result.Value == (rpc.GetAccountInfoResultValue{}) {
+		return sysprog.NonceAccount{}, fmt.Errorf("nonce account %v not found", base58Addr)
+	}
+	accountInfo, err := decodeAccountInfo(res.Result.Value)
+	if err != nil {
+		return sysprog.NonceAccount{}, err
+	}
+	if accountInfo.Owner != common.SystemProgramID.ToBase58() {
+		return sysprog.NonceAccount{}, fmt.Errorf("%v is not a nonce account, owner: %v", base58Addr, accountInfo.Owner)
+	}
+	nonceAccount, err := sysprog.NonceAccountDeserialize(accountInfo.Data)
+	if err != nil {
+		return sysprog.NonceAccount{}, err
+	}
+	if nonceAccount.State != sysprog.NonceAccountStateInitialized {
+		return sysprog.NonceAccount{}, fmt.Errorf("nonce account %v is not initialized", base58Addr)
+	}
+	return nonceAccount, nil
+}
+
+type DurableNonceTransactionParam struct {
+	Instructions []types.Instruction
+	Signers      []types.Signer
+	FeePayer     common.PublicKey
+	NonceAccount common.PublicKey
+	// NonceAuthority signs the nonce advance. default: the authority of the nonce account
+	NonceAuthority common.PublicKey
+}
+
+// BuildDurableNonceTransaction fetches the nonce account and builds a transaction which uses the nonce as its
+// recent blockhash and advances it in the first instruction. Signers may be partial, the missing signatures can be
+// added later, e.g. offline by Transaction.PartialSign, since the transaction doesn't expire until the nonce advances.
+func (c *Client) BuildDurableNonceTransaction(ctx context.Context, param DurableNonceTransactionParam) (types.Transaction, error) {
+	nonceAccount, err := c.GetNonceAccount(ctx, param.NonceAccount.ToBase58())
+	if err != nil {
+		return types.Transaction{}, fmt.Errorf("failed to get nonce account, err: %w", err)
+	}
+	nonceAuthority := param.NonceAuthority
+	if nonceAuthority == (common.PublicKey{}) {
+		nonceAuthority = nonceAccount.AuthorizedPubkey
+	}
+
+	instructions := make([]types.Instruction, 0, 1+len(param.Instructions))
+	instructions = append(instructions, sysprog.AdvanceNonceAccount(param.NonceAccount, nonceAuthority))
+	instructions = append(instructions, param.Instructions...)
+
+	tx, err := types.NewTransactionWithSigners(
+		ctx,
+		types.NewMessage(param.FeePayer, instructions, nonceAccount.Nonce.ToBase58()),
+		param.Signers,
+	)
+	if err != nil {
+		return types.Transaction{}, fmt.Errorf("failed to build tx, err: %v", err)
+	}
+	return tx, nil
+}
+
+// SendAndConfirmDurableNonceTransaction builds a durable nonce transaction, sends it and waits until it
+// reaches the commitment. See SendAndConfirmDurableNonceRawTransaction for the returned errors.
+func (c *Client) SendAndConfirmDurableNonceTransaction(ctx context.Context, param DurableNonceTransactionParam, cfg SendAndConfirmConfig) (string, error) {
+	tx, err := c.BuildDurableNonceTransaction(ctx, param)
+	if err != nil {
+		return "", err
+	}
+	if missing := tx.MissingSigners(); len(missing) > 0 {
+		return "", fmt.Errorf("failed to build tx, lack signatures of %v", missing)
+	}
+	rawTx, err := tx.Serialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize tx, err: %v", err)
+	}
+	return c.SendAndConfirmDurableNonceRawTransaction(ctx, rawTx, cfg)
+}
+
+// SendAndConfirmDurableNonceRawTransaction sends the signed durable nonce transaction, rebroadcasts it until it
+// reaches the commitment and returns its signature. Instead of a blockhash expiry it watches the nonce account,
+// it returns an error wrapping ErrNonceAdvanced if the nonce advances without the transaction, a
+// *TransactionFailedError if the transaction failed on chain, or the error of the first send.
+func (c *Client) SendAndConfirmDurableNonceRawTransaction(ctx context.Context, rawTx []byte, cfg SendAndConfirmConfig) (string, error) {
+	tx, err := types.TransactionDeserialize(rawTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to deserialize tx, err: %v", err)
+	}
+	nonceAccount, err := durableNonceAccount(tx.Message)
+	if err != nil {
+		return "", err
+	}
+	nonce := tx.Message.RecentBlockHash
+
+	cfg = cfg.withDefaults()
+	return c.sendAndConfirm(ctx, rawTx, cfg, func(ctx context.Context, signature string) error {
+		current, err := c.getNonceAccount(ctx, nonceAccount.ToBase58(), cfg.Commitment)
+		if err == nil && current.Nonce.ToBase58() != nonce {
+			return fmt.Errorf("%w, signature: %v, nonce account: %v, nonce: %v, current nonce: %v", ErrNonceAdvanced, signature, nonceAccount, nonce, current.Nonce)
+		}
+		return nil
+	})
+}
+
+// durableNonceAccount returns the nonce account advanced by the first instruction of the message
+func durableNonceAccount(message types.Message) (common.PublicKey, error) {
+	if len(message.Instructions) == 0 {
+		return common.PublicKey{}, errors.New("not a durable nonce transaction, no instructions")
+	}
+	instruction := message.Instructions[0]
+	if instruction.ProgramIDIndex >= len(message.Accounts) ||
+		message.Accounts[instruction.ProgramIDIndex] != common.SystemProgramID ||
+		len(instruction.Data) < 4 ||
+		binary.LittleEndian.Uint32(instruction.Data) != uint32(sysprog.InstructionAdvanceNonceAccount) ||
+		len(instruction.Accounts) == 0 ||
+		instruction.Accounts[0] >= len(message.Accounts) {
+		return common.PublicKey{}, errors.New("not a durable nonce transaction, the first instruction doesn't advance a nonce")
+	}
+	return message.Accounts[instruction.Accounts[0]], nil
+}
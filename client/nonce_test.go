@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/program/sysprog"
+	"github.com/36625090/solana-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func nonceAccountData(authority, nonce common.PublicKey) []byte {
+	data := make([]byte, sysprog.NonceAccountSize)
+	binary.LittleEndian.PutUint32(data[4:8], sysprog.NonceAccountStateInitialized)
+	copy(data[8:40], authority[:])
+	copy(data[40:72], nonce[:])
+	binary.LittleEndian.PutUint64(data[72:], 5000)
+	return data
+}
+
+func TestBuildDurableNonceTransaction(t *testing.T) {
+	feePayer := types.NewAccount()
+	nonceAccount := types.NewAccount().PublicKey
+	nonce := common.PublicKeyFromString("FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+	to := types.NewAccount().PublicKey
+
+	node := &fakeConfirmNode{
+		status: func(polls int) string {
+			if polls < 3 {
+				return "null"
+			}
+			return `{"slot":72,"confirmations":1,"err":null,"confirmationStatus":"confirmed"}`
+		},
+		account: func(int) []byte { return nonceAccountData(feePayer.PublicKey, nonce) },
+	}
+	server := node.serve(t)
+	defer server.Close()
+	c := NewClient(server.URL)
+
+	param := DurableNonceTransactionParam{
+		Instructions: []types.Instruction{sysprog.Transfer(feePayer.PublicKey, to, 1)},
+		Signers:      []types.Signer{feePayer},
+		FeePayer:     feePayer.PublicKey,
+		NonceAccount: nonceAccount,
+	}
+	tx, err := c.BuildDurableNonceTransaction(context.Background(), param)
+	assert.Nil(t, err)
+	assert.Equal(t, nonce.ToBase58(), tx.Message.RecentBlockHash)
+	instructions := tx.Message.DecompileInstructions()
+	assert.Len(t, instructions, 2)
+	// the fee payer is writable in the message, even as the nonce authority
+	assert.Equal(t, sysprog.AdvanceNonceAccount(nonceAccount, feePayer.PublicKey).Data, instructions[0].Data)
+	assert.Equal(t, nonceAccount, instructions[0].Accounts[0].PubKey)
+	assert.Equal(t, feePayer.PublicKey, instructions[0].Accounts[2].PubKey)
+	assert.Nil(t, tx.VerifySignatures())
+
+	got, err := durableNonceAccount(tx.Message)
+	assert.Nil(t, err)
+	assert.Equal(t, nonceAccount, got)
+
+	_, err = c.SendAndConfirmDurableNonceTransaction(context.Background(), param, fastConfirm)
+	assert.Nil(t, err)
+}
+
+func TestSendAndConfirmDurableNonceRawTransactionAdvanced(t *testing.T) {
+	feePayer := types.NewAccount()
+	nonceAccount := types.NewAccount().PublicKey
+	nonce := common.PublicKeyFromString("FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+
+	node := &fakeConfirmNode{
+		status: func(int) string { return "null" },
+		account: func(reads int) []byte {
+			if reads < 2 {
+				return nonceAccountData(feePayer.PublicKey, nonce)
+			}
+			return nonceAccountData(feePayer.PublicKey, types.NewAccount().PublicKey)
+		},
+	}
+	server := node.serve(t)
+	defer server.Close()
+	c := NewClient(server.URL)
+
+	tx, err := c.BuildDurableNonceTransaction(context.Background(), DurableNonceTransactionParam{
+		Instructions: []types.Instruction{sysprog.Transfer(feePayer.PublicKey, nonceAccount, 1)},
+		Signers:      []types.Signer{feePayer},
+		FeePayer:     feePayer.PublicKey,
+		NonceAccount: nonceAccount,
+	})
+	assert.Nil(t, err)
+	rawTx, err := tx.Serialize()
+	assert.Nil(t, err)
+
+	_, err = c.SendAndConfirmDurableNonceRawTransaction(context.Background(), rawTx, fastConfirm)
+	assert.True(t, errors.Is(err, ErrNonceAdvanced))
+	// the one for building and the one which saw the advance
+	assert.Equal(t, 2, node.count("getAccountInfo"))
+
+	_, err = c.SendAndConfirmDurableNonceRawTransaction(context.Background(), func() []byte {
+		tx, _ := types.NewTransaction(types.NewMessage(feePayer.PublicKey, []types.Instruction{sysprog.Transfer(feePayer.PublicKey, nonceAccount, 1)}, nonce.ToBase58()), []types.Account{feePayer})
+		raw, _ := tx.Serialize()
+		return raw
+	}(), fastConfirm)
+	assert.NotNil(t, err)
+}
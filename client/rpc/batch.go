@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var ErrBatchResponseNotFound = errors.New("rpc: no response for the request in batch")
+
+// BatchRequest is a single json rpc call in a batch
+type BatchRequest struct {
+	Method string
+	Params []interface{}
+}
+
+// BatchResponse is the raw response of a single call in a batch. Body has the same format as
+// the body returned by Call so it can be decoded into the same response types.
+type BatchResponse struct {
+	Body  []byte
+	Error error
+}
+
+// CallBatch sends all requests in one http request and matches the responses back by request id.
+// The responses are in the same order as the requests. The error is returned only if the whole batch failed.
+func (c *RpcClient) CallBatch(ctx context.Context, requests []BatchRequest) ([]BatchResponse, error) {
+	if len(requests) == 0 {
+		return []BatchResponse{}, nil
+	}
+
+	payload := make([]jsonRpcRequest, 0, len(requests))
+	methods := make([]string, 0, len(requests))
+	for i, r := range requests {
+		methods = append(methods, r.Method)
+		payload = append(payload, jsonRpcRequest{
+			JsonRpc: "2.0",
+			Id:      uint64(i + 1),
+			Method:  r.Method,
+			Params:  r.Params,
+		})
+	}
+	j, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare payload, err: %v", err)
+	}
+
+	res, err := c.handle(ctx, &Request{
+		Methods: methods,
+		Body:    j,
+		Header:  http.Header{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	body := res.Body
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(body, &entries); err != nil {
+		// the node answers a single error object if it rejects the whole batch
+		var res GeneralResponse
+		if err := json.Unmarshal(body, &res); err == nil && res.Error != nil {
+			return nil, fmt.Errorf("rpc: batch rejected, err: %w", res.Error.RpcError())
+		}
+		return nil, fmt.Errorf("rpc: failed to json decode batch body, err: %v", err)
+	}
+
+	responses := make([]BatchResponse, len(requests))
+	for i := range responses {
+		responses[i].Error = ErrBatchResponseNotFound
+	}
+	for _, entry := range entries {
+		var res GeneralResponse
+		if err := json.Unmarshal(entry, &res); err != nil {
+			continue
+		}
+		if res.ID < 1 || res.ID > uint64(len(requests)) {
+			continue
+		}
+		responses[res.ID-1] = BatchResponse{Body: entry}
+	}
+	return responses, nil
+}
+
+// Batch collects typed calls and sends them in one http request.
+//
+//	b := c.NewBatch()
+//	balance := b.GetBalance(addr)
+//	info := b.GetAccountInfo(addr)
+//	errs, err := b.Send(ctx)
+//
+// The returned responses are filled in by Send.
+type Batch struct {
+	client   *RpcClient
+	requests []BatchRequest
+	handlers []func(body []byte, rpcErr error) error
+}
+
+// NewBatch creates an empty batch
+func (c *RpcClient) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// Len returns the number of calls in the batch
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+// Add appends a raw call, the result is json decoded into res which should be a pointer to a full response struct
+func (b *Batch) Add(res interface{}, method string, params ...interface{}) {
+	b.add(func(body []byte, rpcErr error) error {
+		return b.client.processRpcCall(body, rpcErr, res)
+	}, method, params...)
+}
+
+func (b *Batch) add(handler func(body []byte, rpcErr error) error, method string, params ...interface{}) {
+	b.requests = append(b.requests, BatchRequest{Method: method, Params: params})
+	b.handlers = append(b.handlers, handler)
+}
+
+// Send sends the batch. The errors are per call, in the order calls were added,
+// a json rpc error of a call is returned as *RpcError. The error is returned only if the whole batch failed.
+func (b *Batch) Send(ctx context.Context) ([]error, error) {
+	responses, err := b.client.CallBatch(ctx, b.requests)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(responses))
+	for i, res := range responses {
+		if err := b.handlers[i](res.Body, res.Error); err != nil {
+			errs[i] = err
+			continue
+		}
+		var general GeneralResponse
+		if err := json.Unmarshal(res.Body, &general); err == nil && general.Error != nil {
+			errs[i] = general.Error.RpcError()
+		}
+	}
+	return errs, nil
+}
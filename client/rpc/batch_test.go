@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCallBatch(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `[{"jsonrpc":"2.0","id":1,"method":"getBalance","params":["RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"]},{"jsonrpc":"2.0","id":2,"method":"getSlot"}]`,
+			ResponseBody: `[{"jsonrpc":"2.0","result":77317717,"id":2},{"jsonrpc":"2.0","result":{"context":{"slot":77317716},"value":6999995000},"id":1}]`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.CallBatch(context.Background(), []BatchRequest{
+					{Method: "getBalance", Params: []interface{}{"RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"}},
+					{Method: "getSlot"},
+				})
+			},
+			ExpectedResponse: []BatchResponse{
+				{Body: []byte(`{"jsonrpc":"2.0","result":{"context":{"slot":77317716},"value":6999995000},"id":1}`)},
+				{Body: []byte(`{"jsonrpc":"2.0","result":77317717,"id":2}`)},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `[{"jsonrpc":"2.0","id":1,"method":"getSlot"},{"jsonrpc":"2.0","id":2,"method":"getSlot"}]`,
+			ResponseBody: `[{"jsonrpc":"2.0","result":77317717,"id":1}]`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.CallBatch(context.Background(), []BatchRequest{
+					{Method: "getSlot"},
+					{Method: "getSlot"},
+				})
+			},
+			ExpectedResponse: []BatchResponse{
+				{Body: []byte(`{"jsonrpc":"2.0","result":77317717,"id":1}`)},
+				{Error: ErrBatchResponseNotFound},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
+
+func TestBatch(t *testing.T) {
+	type batchResult struct {
+		Balance *GetBalanceResponse
+		Account *GetAccountInfoResponse
+		Errs    []error
+	}
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `[{"jsonrpc":"2.0","id":1,"method":"getBalance","params":["RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7",{"commitment":"confirmed"}]},{"jsonrpc":"2.0","id":2,"method":"getAccountInfo","params":["FaTGhPTgKeZZzQwLenoxn2VZXPWV1FpjQ1AQe77JUeJw"]}]`,
+			ResponseBody: `[{"jsonrpc":"2.0","result":{"context":{"slot":73914708},"value":6999995000},"id":1},{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: WrongSize"},"id":2}]`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				b := rc.NewBatch()
+				balance := b.GetBalanceWithCfg("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7", GetBalanceConfig{Commitment: CommitmentConfirmed})
+				account := b.GetAccountInfo("FaTGhPTgKeZZzQwLenoxn2VZXPWV1FpjQ1AQe77JUeJw")
+				errs, err := b.Send(context.Background())
+				return batchResult{Balance: balance, Account: account, Errs: errs}, err
+			},
+			ExpectedResponse: batchResult{
+				Balance: &GetBalanceResponse{
+					GeneralResponse: GeneralResponse{
+						JsonRPC: "2.0",
+						ID:      1,
+					},
+					Result: GetBalanceResult{
+						Context: Context{Slot: 73914708},
+						Value:   6999995000,
+					},
+				},
+				Account: &GetAccountInfoResponse{
+					GeneralResponse: GeneralResponse{
+						JsonRPC: "2.0",
+						ID:      2,
+						Error: &ErrorResponse{
+							Code:    -32602,
+							Message: "Invalid param: WrongSize",
+						},
+					},
+				},
+				Errs: []error{
+					nil,
+					&RpcError{
+						Code:    -32602,
+						Message: "Invalid param: WrongSize",
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -2,11 +2,14 @@ package rpc
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
 const (
@@ -17,11 +20,30 @@ const (
 )
 
 type RpcClient struct {
-	endpoint string
+	endpoint    string
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	timeout     *time.Duration
+	header      http.Header
+	headerFuncs []func(ctx context.Context, header http.Header) error
+	gzip        bool
+	retry       *RetryPolicy
+	limiter     *rateLimiter
+	failover    *Failover
+	middlewares []Middleware
 }
 
-func NewRpcClient(endpoint string) RpcClient {
-	return RpcClient{endpoint: endpoint}
+// NewRpcClient creates a client of the endpoint. The http client is shared by every rpc method and copies of the RpcClient.
+func NewRpcClient(endpoint string, opts ...Option) RpcClient {
+	c := RpcClient{
+		endpoint: endpoint,
+		header:   http.Header{},
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	c.buildHTTPClient()
+	return c
 }
 
 // Call will return body of response. if http code beyond 200~300, the error also returns.
@@ -31,34 +53,136 @@ func (c *RpcClient) Call(ctx context.Context, params ...interface{}) ([]byte, er
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare payload, err: %v", err)
 	}
+	res, err := c.handle(ctx, &Request{
+		Methods: []string{params[0].(string)},
+		Body:    j,
+		Header:  http.Header{},
+	})
+	if res == nil {
+		return nil, err
+	}
+	return res.Body, err
+}
+
+// handle passes the request through the middlewares to send
+func (c *RpcClient) handle(ctx context.Context, req *Request) (*Response, error) {
+	h := Handler(c.send)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+	return h(ctx, req)
+}
+
+func (c *RpcClient) send(ctx context.Context, req *Request) (*Response, error) {
+	if c.failover != nil {
+		return c.failover.send(ctx, req)
+	}
+	return c.sendWithRetry(ctx, req)
+}
+
+func (c *RpcClient) sendWithRetry(ctx context.Context, req *Request) (*Response, error) {
+	res, err := c.doWithRetry(ctx, req)
+	return res.response(), err
+}
+
+func (c *RpcClient) doWithRetry(ctx context.Context, req *Request) (httpResult, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx); err != nil {
+				return httpResult{}, fmt.Errorf("failed to wait for rate limiter, err: %v", err)
+			}
+		}
+		res, err := c.do(ctx, req)
+		if c.retry == nil || attempt >= c.retry.MaxRetries || !c.retry.shouldRetry(ctx, res, err) {
+			return res, err
+		}
+		if sleepErr := sleep(ctx, c.retry.backoff(attempt, res.retryAfter)); sleepErr != nil {
+			return res, err
+		}
+	}
+}
+
+type httpResult struct {
+	body       []byte
+	statusCode int
+	header     http.Header
+	retryAfter time.Duration
+	// transportErr is set when the request failed before any response was received
+	transportErr bool
+}
+
+func (r httpResult) response() *Response {
+	if r.statusCode == 0 {
+		return nil
+	}
+	return &Response{
+		StatusCode: r.statusCode,
+		Header:     r.header,
+		Body:       r.body,
+	}
+}
 
+func (c *RpcClient) do(ctx context.Context, r *Request) (httpResult, error) {
 	// prepare request
-	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(j))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(r.Body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to do http.NewRequestWithContext, err: %v", err)
+		return httpResult{}, fmt.Errorf("failed to do http.NewRequestWithContext, err: %v", err)
+	}
+	// copy the values, header funcs may append to them
+	for k, v := range c.header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	for k, v := range r.Header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	for _, f := range c.headerFuncs {
+		if err := f(ctx, req.Header); err != nil {
+			return httpResult{}, fmt.Errorf("failed to set header, err: %v", err)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.gzip {
+		req.Header.Set("Accept-Encoding", "gzip")
 	}
-	req.Header.Add("Content-Type", "application/json")
 
 	// do request
-	httpclient := &http.Client{}
+	httpclient := c.httpClient
+	if httpclient == nil {
+		httpclient = http.DefaultClient
+	}
 	res, err := httpclient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to do request, err: %v", err)
+		return httpResult{transportErr: true}, fmt.Errorf("failed to do request, err: %v", err)
 	}
 	defer res.Body.Close()
+	result := httpResult{
+		statusCode: res.StatusCode,
+		header:     res.Header,
+		retryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+	}
 
 	// parse body
-	body, err := ioutil.ReadAll(res.Body)
+	var reader io.Reader = res.Body
+	if res.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(res.Body)
+		if err != nil {
+			return result, fmt.Errorf("failed to read gzip body, err: %v", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+	body, err := ioutil.ReadAll(reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read body, err: %v", err)
+		return result, fmt.Errorf("failed to read body, err: %v", err)
 	}
+	result.body = body
 
 	// check response code
 	if res.StatusCode < 200 || res.StatusCode > 300 {
-		return body, fmt.Errorf("get status code: %v", res.StatusCode)
+		return result, fmt.Errorf("get status code: %v", res.StatusCode)
 	}
 
-	return body, nil
+	return result, nil
 }
 
 type jsonRpcRequest struct {
@@ -82,48 +206,17 @@ func preparePayload(params []interface{}) ([]byte, error) {
 	return j, nil
 }
 
-// TODO use Call
-func (s *RpcClient) request(ctx context.Context, method string, params []interface{}, response interface{}) error {
-	// post data
-	j, err := json.Marshal(map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      0,
-		"method":  method,
-		"params":  params,
-	})
-	if err != nil {
+// request calls the method and decodes the body into response. A json rpc error in the body is returned as *RpcError.
+func (c *RpcClient) request(ctx context.Context, method string, params []interface{}, response interface{}) error {
+	body, err := c.Call(ctx, append([]interface{}{method}, params...)...)
+	if err := c.processRpcCall(body, err, response); err != nil {
 		return err
 	}
 
-	// post request
-	req, err := http.NewRequestWithContext(ctx, "POST", s.endpoint, bytes.NewBuffer(j))
-	if err != nil {
-		return err
-	}
-	req.Header.Add("Content-Type", "application/json")
-
-	// http client and send request
-	httpclient := &http.Client{}
-	res, err := httpclient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
-
-	// parse body
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return err
-	}
-	if len(body) != 0 {
-		if err := json.Unmarshal(body, &response); err != nil {
-			return err
-		}
-	}
-
-	// return result
-	if res.StatusCode < 200 || res.StatusCode > 300 {
-		return fmt.Errorf("get status code: %d", res.StatusCode)
+	// return rpc error
+	var res GeneralResponse
+	if err := json.Unmarshal(body, &res); err == nil && res.Error != nil {
+		return res.Error.RpcError()
 	}
 	return nil
 }
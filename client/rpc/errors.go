@@ -0,0 +1,250 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// json rpc error codes, the -320xx ones are defined by solana
+const (
+	ErrorCodeParseError     = -32700
+	ErrorCodeInvalidRequest = -32600
+	ErrorCodeMethodNotFound = -32601
+	ErrorCodeInvalidParams  = -32602
+	ErrorCodeInternalError  = -32603
+
+	ErrorCodeBlockCleanedUp                           = -32001
+	ErrorCodeSendTransactionPreflightFailure          = -32002
+	ErrorCodeTransactionSignatureVerificationFailure  = -32003
+	ErrorCodeBlockNotAvailable                        = -32004
+	ErrorCodeNodeUnhealthy                            = -32005
+	ErrorCodeTransactionPrecompileVerificationFailure = -32006
+	ErrorCodeSlotSkipped                              = -32007
+	ErrorCodeNoSnapshot                               = -32008
+	ErrorCodeLongTermStorageSlotSkipped               = -32009
+	ErrorCodeKeyExcludedFromSecondaryIndex            = -32010
+	ErrorCodeTransactionHistoryNotAvailable           = -32011
+	ErrorCodeScanError                                = -32012
+	ErrorCodeTransactionSignatureLenMismatch          = -32013
+	ErrorCodeBlockStatusNotAvailableYet               = -32014
+	ErrorCodeUnsupportedTransactionVersion            = -32015
+	ErrorCodeMinContextSlotNotReached                 = -32016
+)
+
+// frequently-used kinds of TransactionError
+const (
+	TransactionErrorAccountInUse                 = "AccountInUse"
+	TransactionErrorAccountNotFound              = "AccountNotFound"
+	TransactionErrorAlreadyProcessed             = "AlreadyProcessed"
+	TransactionErrorBlockhashNotFound            = "BlockhashNotFound"
+	TransactionErrorInstructionError             = "InstructionError"
+	TransactionErrorInsufficientFundsForFee      = "InsufficientFundsForFee"
+	TransactionErrorInsufficientFundsForRent     = "InsufficientFundsForRent"
+	TransactionErrorInvalidAccountForFee         = "InvalidAccountForFee"
+	TransactionErrorSignatureFailure             = "SignatureFailure"
+	TransactionErrorProgramAccountNotFound       = "ProgramAccountNotFound"
+	TransactionErrorWouldExceedMaxBlockCostLimit = "WouldExceedMaxBlockCostLimit"
+)
+
+// RpcError is a json rpc error response. Use errors.As to get it from the errors returned by this package.
+type RpcError struct {
+	Code    int
+	Message string
+	// Data is decoded by Code
+	//  ErrorCodeSendTransactionPreflightFailure: *SendTransactionPreflightFailure
+	//  ErrorCodeNodeUnhealthy: *NodeUnhealthy
+	//  others: the raw json value, nil if absent
+	Data interface{}
+}
+
+func (e *RpcError) Error() string {
+	return fmt.Sprintf("rpc response error, code: %v, message: %v", e.Code, e.Message)
+}
+
+// Unwrap returns the TransactionError of a preflight failure so it can be matched by errors.As directly
+func (e *RpcError) Unwrap() error {
+	if failure, ok := e.Data.(*SendTransactionPreflightFailure); ok && failure.Err != nil {
+		return failure.Err
+	}
+	return nil
+}
+
+// IsNodeUnhealthy reports the node is behind or not ready
+func (e *RpcError) IsNodeUnhealthy() bool {
+	return e.Code == ErrorCodeNodeUnhealthy
+}
+
+// IsPreflightFailure reports the transaction failed the simulation of sendTransaction
+func (e *RpcError) IsPreflightFailure() bool {
+	return e.Code == ErrorCodeSendTransactionPreflightFailure
+}
+
+// IsBlockhashNotFound reports the recent blockhash of the transaction is unknown or expired
+func (e *RpcError) IsBlockhashNotFound() bool {
+	var txErr *TransactionError
+	return errors.As(e, &txErr) && txErr.Kind == TransactionErrorBlockhashNotFound
+}
+
+// SendTransactionPreflightFailure is the error data of ErrorCodeSendTransactionPreflightFailure
+type SendTransactionPreflightFailure struct {
+	Err           *TransactionError `json:"err"`
+	Logs          []string          `json:"logs"`
+	Accounts      []interface{}     `json:"accounts"`
+	UnitsConsumed *uint64           `json:"unitsConsumed"`
+}
+
+// NodeUnhealthy is the error data of ErrorCodeNodeUnhealthy
+type NodeUnhealthy struct {
+	NumSlotsBehind *uint64 `json:"numSlotsBehind"`
+}
+
+// RpcError converts the error response to an error
+func (e *ErrorResponse) RpcError() *RpcError {
+	rpcErr := &RpcError{
+		Code:    e.Code,
+		Message: e.Message,
+	}
+	if e.Data == nil {
+		return rpcErr
+	}
+	rpcErr.Data = e.Data
+
+	var data interface{}
+	switch e.Code {
+	case ErrorCodeSendTransactionPreflightFailure:
+		data = new(SendTransactionPreflightFailure)
+	case ErrorCodeNodeUnhealthy:
+		data = new(NodeUnhealthy)
+	default:
+		return rpcErr
+	}
+	b, err := json.Marshal(e.Data)
+	if err != nil {
+		return rpcErr
+	}
+	if err := json.Unmarshal(b, data); err != nil {
+		return rpcErr
+	}
+	rpcErr.Data = data
+	return rpcErr
+}
+
+// TransactionError is the `err` of a transaction status, a simulation or a preflight failure
+type TransactionError struct {
+	// Kind is the variant name, e.g. TransactionErrorBlockhashNotFound
+	Kind string
+	// InstructionError is set if Kind is TransactionErrorInstructionError
+	InstructionError *InstructionError
+	// Detail is the payload of other kinds, e.g. {"account_index":2} of InsufficientFundsForRent
+	Detail interface{}
+}
+
+// InstructionError is an error returned by the instruction at Index
+type InstructionError struct {
+	Index int
+	// Kind is the variant name, e.g. "Custom", "InvalidAccountData"
+	Kind string
+	// Custom is the program error code if Kind is "Custom"
+	Custom *uint32
+	Detail interface{}
+}
+
+func (e *TransactionError) Error() string {
+	if e.InstructionError != nil {
+		return fmt.Sprintf("transaction error: %v", e.InstructionError.Error())
+	}
+	if e.Detail != nil {
+		return fmt.Sprintf("transaction error: %v, %v", e.Kind, e.Detail)
+	}
+	return fmt.Sprintf("transaction error: %v", e.Kind)
+}
+
+func (e *InstructionError) Error() string {
+	if e.Custom != nil {
+		return fmt.Sprintf("instruction #%d failed, custom program error: 0x%x", e.Index, *e.Custom)
+	}
+	if e.Detail != nil {
+		return fmt.Sprintf("instruction #%d failed, %v: %v", e.Index, e.Kind, e.Detail)
+	}
+	return fmt.Sprintf("instruction #%d failed, %v", e.Index, e.Kind)
+}
+
+func (e *TransactionError) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	txErr, err := ParseTransactionError(v)
+	if err != nil {
+		return err
+	}
+	if txErr == nil {
+		*e = TransactionError{}
+		return nil
+	}
+	*e = *txErr
+	return nil
+}
+
+// ParseTransactionError decodes a json decoded `err` field, e.g. TransactionMeta.Err. It returns nil if v is nil.
+func ParseTransactionError(v interface{}) (*TransactionError, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return &TransactionError{Kind: v}, nil
+	case map[string]interface{}:
+		kind, detail, err := singleEntry(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse transaction error, err: %v", err)
+		}
+		if kind != TransactionErrorInstructionError {
+			return &TransactionError{Kind: kind, Detail: detail}, nil
+		}
+		instructionErr, err := parseInstructionError(detail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse instruction error, err: %v", err)
+		}
+		return &TransactionError{Kind: kind, InstructionError: instructionErr}, nil
+	}
+	return nil, fmt.Errorf("unexpected transaction error: %v", v)
+}
+
+func parseInstructionError(v interface{}) (*InstructionError, error) {
+	pair, ok := v.([]interface{})
+	if !ok || len(pair) != 2 {
+		return nil, fmt.Errorf("unexpected format: %v", v)
+	}
+	index, ok := pair[0].(float64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected index: %v", pair[0])
+	}
+	switch e := pair[1].(type) {
+	case string:
+		return &InstructionError{Index: int(index), Kind: e}, nil
+	case map[string]interface{}:
+		kind, detail, err := singleEntry(e)
+		if err != nil {
+			return nil, err
+		}
+		instructionErr := &InstructionError{Index: int(index), Kind: kind, Detail: detail}
+		if code, ok := detail.(float64); ok && kind == "Custom" {
+			custom := uint32(code)
+			instructionErr.Custom = &custom
+			instructionErr.Detail = nil
+		}
+		return instructionErr, nil
+	}
+	return nil, fmt.Errorf("unexpected error: %v", pair[1])
+}
+
+func singleEntry(m map[string]interface{}) (string, interface{}, error) {
+	if len(m) != 1 {
+		return "", nil, fmt.Errorf("expected a single variant, got: %v", m)
+	}
+	for k, v := range m {
+		return k, v, nil
+	}
+	return "", nil, nil
+}
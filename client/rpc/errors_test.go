@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorResponseRpcError(t *testing.T) {
+	custom := uint32(1)
+	numSlotsBehind := uint64(42)
+	tests := []struct {
+		name     string
+		response string
+		want     *RpcError
+	}{
+		{
+			name:     "preflight failure with instruction error",
+			response: `{"code":-32002,"message":"Transaction simulation failed: Error processing Instruction 0: custom program error: 0x1","data":{"accounts":null,"err":{"InstructionError":[0,{"Custom":1}]},"logs":["Program 11111111111111111111111111111111 invoke [1]","Transfer: insufficient lamports 0, need 1","Program 11111111111111111111111111111111 failed: custom program error: 0x1"]}}`,
+			want: &RpcError{
+				Code:    -32002,
+				Message: "Transaction simulation failed: Error processing Instruction 0: custom program error: 0x1",
+				Data: &SendTransactionPreflightFailure{
+					Err: &TransactionError{
+						Kind: TransactionErrorInstructionError,
+						InstructionError: &InstructionError{
+							Index:  0,
+							Kind:   "Custom",
+							Custom: &custom,
+						},
+					},
+					Logs: []string{
+						"Program 11111111111111111111111111111111 invoke [1]",
+						"Transfer: insufficient lamports 0, need 1",
+						"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+					},
+				},
+			},
+		},
+		{
+			name:     "node is behind",
+			response: `{"code":-32005,"message":"Node is behind by 42 slots","data":{"numSlotsBehind":42}}`,
+			want: &RpcError{
+				Code:    -32005,
+				Message: "Node is behind by 42 slots",
+				Data:    &NodeUnhealthy{NumSlotsBehind: &numSlotsBehind},
+			},
+		},
+		{
+			name:     "without data",
+			response: `{"code":-32602,"message":"Invalid params"}`,
+			want: &RpcError{
+				Code:    -32602,
+				Message: "Invalid params",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res ErrorResponse
+			assert.Nil(t, json.Unmarshal([]byte(tt.response), &res))
+			assert.Equal(t, tt.want, res.RpcError())
+		})
+	}
+}
+
+func TestRpcErrorAs(t *testing.T) {
+	server := `{"jsonrpc":"2.0","error":{"code":-32002,"message":"Transaction simulation failed: Blockhash not found","data":{"accounts":null,"err":"BlockhashNotFound","logs":[]}},"id":1}`
+	res := GeneralResponse{}
+	assert.Nil(t, json.Unmarshal([]byte(server), &res))
+
+	var err error = res.Error.RpcError()
+
+	var rpcErr *RpcError
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.True(t, rpcErr.IsPreflightFailure())
+	assert.True(t, rpcErr.IsBlockhashNotFound())
+	assert.False(t, rpcErr.IsNodeUnhealthy())
+
+	var txErr *TransactionError
+	assert.True(t, errors.As(err, &txErr))
+	assert.Equal(t, TransactionErrorBlockhashNotFound, txErr.Kind)
+}
+
+func TestParseTransactionError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     string
+		want    *TransactionError
+		wantMsg string
+	}{
+		{
+			name:    "kind only",
+			err:     `"AccountInUse"`,
+			want:    &TransactionError{Kind: TransactionErrorAccountInUse},
+			wantMsg: "transaction error: AccountInUse",
+		},
+		{
+			name:    "instruction error",
+			err:     `{"InstructionError":[2,"InvalidAccountData"]}`,
+			want:    &TransactionError{Kind: TransactionErrorInstructionError, InstructionError: &InstructionError{Index: 2, Kind: "InvalidAccountData"}},
+			wantMsg: "transaction error: instruction #2 failed, InvalidAccountData",
+		},
+		{
+			name:    "instruction error with detail",
+			err:     `{"InstructionError":[1,{"BorshIoError":"Unknown"}]}`,
+			want:    &TransactionError{Kind: TransactionErrorInstructionError, InstructionError: &InstructionError{Index: 1, Kind: "BorshIoError", Detail: "Unknown"}},
+			wantMsg: "transaction error: instruction #1 failed, BorshIoError: Unknown",
+		},
+		{
+			name:    "kind with detail",
+			err:     `{"InsufficientFundsForRent":{"account_index":2}}`,
+			want:    &TransactionError{Kind: TransactionErrorInsufficientFundsForRent, Detail: map[string]interface{}{"account_index": float64(2)}},
+			wantMsg: "transaction error: InsufficientFundsForRent, map[account_index:2]",
+		},
+		{
+			name: "no error",
+			err:  `null`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v interface{}
+			assert.Nil(t, json.Unmarshal([]byte(tt.err), &v))
+			got, err := ParseTransactionError(v)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.want != nil {
+				assert.EqualError(t, got, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRequestRpcError(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0","id":1,"method":"getBlockHeight","params":[{}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is unhealthy","data":{}},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetBlockHeight(context.Background(), GetBlockHeightConfig{})
+			},
+			ExpectedResponse: uint64(0),
+			ExpectedError: &RpcError{
+				Code:    -32005,
+				Message: "Node is unhealthy",
+				Data:    &NodeUnhealthy{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+	defaultMaxSlotLag          = 50
+
+	// failoverLatencyTolerance is the width of the latency buckets endpoints are ranked by
+	failoverLatencyTolerance = 50 * time.Millisecond
+)
+
+// DefaultWriteMethods are routed to the write endpoints of a Failover
+var DefaultWriteMethods = []string{"sendTransaction", "requestAirdrop"}
+
+// FailoverConfig is a config for Failover
+type FailoverConfig struct {
+	Endpoints []string
+	// WriteEndpoints receive write methods exclusively. default: Endpoints
+	WriteEndpoints []string
+	// WriteMethods default: DefaultWriteMethods
+	WriteMethods []string
+	// HealthCheckInterval is the minimum interval between two health checks, they are triggered by requests. default: 10s
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout default: 5s
+	HealthCheckTimeout time.Duration
+	// MaxSlotLag is how many slots an endpoint can be behind the highest one and still be healthy. default: 50
+	MaxSlotLag uint64
+}
+
+// EndpointStatus is the latest known state of an endpoint
+type EndpointStatus struct {
+	Endpoint  string
+	Healthy   bool
+	Slot      uint64
+	SlotLag   uint64
+	Latency   time.Duration
+	Failures  int // consecutive failed requests
+	LastError error
+	CheckedAt time.Time
+}
+
+// Failover spreads requests over several endpoints. Reads go to the healthiest endpoint, writes to
+// the write endpoints. A request which fails with a network error, a retryable status code or a
+// retryable json rpc error code is sent to the next endpoint, other errors are returned right away.
+type Failover struct {
+	cfg          FailoverConfig
+	rpcClient    RpcClient
+	writeMethods map[string]bool
+
+	mu        sync.Mutex
+	endpoints []*failoverEndpoint
+	checking  bool
+	checkedAt time.Time
+}
+
+type failoverEndpoint struct {
+	client RpcClient
+	write  bool
+	status EndpointStatus
+}
+
+// NewFailover creates a failover over the endpoints, the options are applied to the client of every endpoint
+func NewFailover(cfg FailoverConfig, opts ...Option) (*Failover, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, errors.New("no endpoints provided")
+	}
+	if cfg.WriteMethods == nil {
+		cfg.WriteMethods = DefaultWriteMethods
+	}
+	if cfg.HealthCheckInterval <= 0 {
+		cfg.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if cfg.HealthCheckTimeout <= 0 {
+		cfg.HealthCheckTimeout = defaultHealthCheckTimeout
+	}
+	if cfg.MaxSlotLag == 0 {
+		cfg.MaxSlotLag = defaultMaxSlotLag
+	}
+
+	writeEndpoints := map[string]bool{}
+	for _, endpoint := range cfg.WriteEndpoints {
+		writeEndpoints[endpoint] = true
+	}
+	f := &Failover{
+		cfg:          cfg,
+		writeMethods: map[string]bool{},
+	}
+	for _, method := range cfg.WriteMethods {
+		f.writeMethods[method] = true
+	}
+	for _, endpoint := range cfg.Endpoints {
+		f.endpoints = append(f.endpoints, &failoverEndpoint{
+			client: NewRpcClient(endpoint, opts...),
+			write:  len(cfg.WriteEndpoints) == 0 || writeEndpoints[endpoint],
+			status: EndpointStatus{Endpoint: endpoint, Healthy: true},
+		})
+		delete(writeEndpoints, endpoint)
+	}
+	for endpoint := range writeEndpoints {
+		return nil, fmt.Errorf("write endpoint %v is not in endpoints", endpoint)
+	}
+
+	f.rpcClient = NewRpcClient(cfg.Endpoints[0], opts...)
+	f.rpcClient.failover = f
+	return f, nil
+}
+
+// RpcClient returns a client which sends every request through the failover
+func (f *Failover) RpcClient() RpcClient {
+	return f.rpcClient
+}
+
+// Statuses returns the latest known state of every endpoint
+func (f *Failover) Statuses() []EndpointStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	statuses := make([]EndpointStatus, 0, len(f.endpoints))
+	for _, e := range f.endpoints {
+		statuses = append(statuses, e.status)
+	}
+	return statuses
+}
+
+// CheckHealth checks every endpoint by `getHealth` and compares their slots
+func (f *Failover) CheckHealth(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, f.cfg.HealthCheckTimeout)
+	defer cancel()
+
+	type result struct {
+		slot    uint64
+		latency time.Duration
+		err     error
+	}
+	results := make([]result, len(f.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range f.endpoints {
+		wg.Add(1)
+		go func(i int, c RpcClient) {
+			defer wg.Done()
+			start := time.Now()
+			health, err := c.GetHealth(ctx)
+			if err == nil && health.Error != nil {
+				err = health.Error.RpcError()
+			}
+			if err != nil {
+				results[i] = result{err: err}
+				return
+			}
+			slot, err := c.GetSlotWithCfg(ctx, GetSlotConfig{Commitment: CommitmentProcessed})
+			if err == nil && slot.Error != nil {
+				err = slot.Error.RpcError()
+			}
+			results[i] = result{slot: slot.Result, latency: time.Since(start), err: err}
+		}(i, e.client)
+	}
+	wg.Wait()
+
+	var maxSlot uint64
+	for _, r := range results {
+		if r.err == nil && r.slot > maxSlot {
+			maxSlot = r.slot
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	for i, e := range f.endpoints {
+		r := results[i]
+		e.status.CheckedAt = now
+		if r.err != nil {
+			e.status.Healthy = false
+			e.status.LastError = r.err
+			continue
+		}
+		e.status.Slot = r.slot
+		e.status.SlotLag = maxSlot - r.slot
+		e.status.Latency = r.latency
+		e.status.Healthy = e.status.SlotLag <= f.cfg.MaxSlotLag
+		// the endpoint answers again, earlier failed requests must not keep it ranked last
+		e.status.Failures = 0
+		e.status.LastError = nil
+		if !e.status.Healthy {
+			e.status.LastError = fmt.Errorf("behind by %v slots", e.status.SlotLag)
+		}
+	}
+	f.checkedAt = now
+}
+
+func (f *Failover) maybeCheckHealth() {
+	f.mu.Lock()
+	if f.checking || time.Since(f.checkedAt) < f.cfg.HealthCheckInterval {
+		f.mu.Unlock()
+		return
+	}
+	f.checking = true
+	f.mu.Unlock()
+
+	go func() {
+		f.CheckHealth(context.Background())
+		f.mu.Lock()
+		f.checking = false
+		f.mu.Unlock()
+	}()
+}
+
+// candidates returns endpoints ordered by preference
+func (f *Failover) candidates(write bool) []*failoverEndpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	list := make([]*failoverEndpoint, 0, len(f.endpoints))
+	for _, e := range f.endpoints {
+		if write && !e.write {
+			continue
+		}
+		list = append(list, e)
+	}
+	statuses := make(map[*failoverEndpoint]EndpointStatus, len(list))
+	for _, e := range list {
+		statuses[e] = e.status
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := statuses[list[i]], statuses[list[j]]
+		if a.Healthy != b.Healthy {
+			return a.Healthy
+		}
+		if a.Failures != b.Failures {
+			return a.Failures < b.Failures
+		}
+		if a.SlotLag != b.SlotLag {
+			return a.SlotLag < b.SlotLag
+		}
+		// latencies are noisy, close ones keep the configured order
+		return a.Latency/failoverLatencyTolerance < b.Latency/failoverLatencyTolerance
+	})
+	return list
+}
+
+func (f *Failover) report(e *failoverEndpoint, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		e.status.Failures = 0
+		return
+	}
+	e.status.Failures++
+	e.status.LastError = err
+}
+
+func (f *Failover) send(ctx context.Context, req *Request) (*Response, error) {
+	f.maybeCheckHealth()
+
+	var res httpResult
+	var err error
+	for _, e := range f.candidates(f.isWrite(req.Methods)) {
+		res, err = e.client.doWithRetry(ctx, req)
+		if ctx.Err() != nil {
+			return res.response(), err
+		}
+		failure := retryableFailure(e.client, res, err)
+		if failure == nil && err != nil {
+			// the request itself is rejected, the other endpoints would do the same
+			return res.response(), err
+		}
+		f.report(e, failure)
+		if failure == nil {
+			return res.response(), err
+		}
+	}
+	return res.response(), err
+}
+
+// retryableFailure returns the error of a failed request which another endpoint may serve
+func retryableFailure(c RpcClient, res httpResult, err error) error {
+	if err == nil {
+		return retryableResponseError(res.body)
+	}
+	statusCodes := DefaultRetryableStatusCodes
+	if c.retry != nil {
+		statusCodes = c.retry.RetryableStatusCodes
+	}
+	if res.transportErr || containsInt(statusCodes, res.statusCode) {
+		return err
+	}
+	return nil
+}
+
+func (f *Failover) isWrite(methods []string) bool {
+	for _, method := range methods {
+		if f.writeMethods[method] {
+			return true
+		}
+	}
+	return false
+}
+
+// retryableResponseError reports a node side error which another endpoint may not have
+func retryableResponseError(body []byte) error {
+	var res GeneralResponse
+	if json.Unmarshal(body, &res) != nil || res.Error == nil {
+		return nil
+	}
+	if !IsRetryableErrorCode(res.Error.Code) {
+		return nil
+	}
+	return res.Error.RpcError()
+}
@@ -0,0 +1,226 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeNode struct {
+	server *httptest.Server
+	mu     sync.Mutex
+	slot   uint64
+	down   bool
+	// statusCode answers every request with the status code if set
+	statusCode int
+	calls      map[string]int
+}
+
+func newFakeNode(slot uint64) *fakeNode {
+	n := &fakeNode{slot: slot, calls: map[string]int{}}
+	n.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		var r jsonRpcRequest
+		json.Unmarshal(body, &r)
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		n.calls[r.Method]++
+		if n.down {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if n.statusCode != 0 {
+			rw.WriteHeader(n.statusCode)
+			return
+		}
+		switch r.Method {
+		case "getHealth":
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":"ok","id":1}`))
+		case "getSlot":
+			rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":%d,"id":1}`, n.slot)))
+		case "sendTransaction":
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":"5rgpegm86vwXotD2Z7WWW1DxpSxmWGQ9g4RMoBJvxJ2xiVF6TNCvGseZ3A1uisew9tGrdKirkkHUGjQW8uNqz9BW","id":1}`))
+		default:
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":6999995000},"id":1}`))
+		}
+	}))
+	return n
+}
+
+func (n *fakeNode) count(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+func TestFailover(t *testing.T) {
+	down := newFakeNode(1000)
+	down.down = true
+	defer down.server.Close()
+	lagging := newFakeNode(900)
+	defer lagging.server.Close()
+	healthy := newFakeNode(1000)
+	defer healthy.server.Close()
+
+	f, err := NewFailover(FailoverConfig{
+		Endpoints:           []string{down.server.URL, lagging.server.URL, healthy.server.URL},
+		WriteEndpoints:      []string{down.server.URL, lagging.server.URL},
+		HealthCheckInterval: time.Hour,
+	})
+	assert.Nil(t, err)
+	f.CheckHealth(context.Background())
+
+	statuses := f.Statuses()
+	assert.False(t, statuses[0].Healthy)
+	assert.False(t, statuses[1].Healthy)
+	assert.Equal(t, uint64(100), statuses[1].SlotLag)
+	assert.True(t, statuses[2].Healthy)
+
+	// reads go to the healthiest endpoint
+	c := f.RpcClient()
+	res, err := c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6999995000), res.Result.Value)
+	assert.Equal(t, 1, healthy.count("getBalance"))
+	assert.Equal(t, 0, down.count("getBalance"))
+
+	// writes are pinned to the write endpoints, the down one is tried and failed over
+	sig, err := c.SendTransaction(context.Background(), "raw")
+	assert.Nil(t, err)
+	assert.Equal(t, "5rgpegm86vwXotD2Z7WWW1DxpSxmWGQ9g4RMoBJvxJ2xiVF6TNCvGseZ3A1uisew9tGrdKirkkHUGjQW8uNqz9BW", sig.Result)
+	assert.Equal(t, 1, lagging.count("sendTransaction"))
+	assert.Equal(t, 0, healthy.count("sendTransaction"))
+}
+
+func TestFailoverOnError(t *testing.T) {
+	first := newFakeNode(1000)
+	defer first.server.Close()
+	second := newFakeNode(1000)
+	defer second.server.Close()
+
+	f, err := NewFailover(FailoverConfig{
+		Endpoints:           []string{first.server.URL, second.server.URL},
+		HealthCheckInterval: time.Hour,
+	})
+	assert.Nil(t, err)
+	f.CheckHealth(context.Background())
+
+	first.mu.Lock()
+	first.down = true
+	first.mu.Unlock()
+
+	c := f.RpcClient()
+	_, err = c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, first.count("getBalance"))
+	assert.Equal(t, 1, second.count("getBalance"))
+	assert.Equal(t, 1, f.Statuses()[0].Failures)
+
+	// the failed endpoint is moved back
+	_, err = c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, first.count("getBalance"))
+	assert.Equal(t, 2, second.count("getBalance"))
+}
+
+func TestNewFailover(t *testing.T) {
+	_, err := NewFailover(FailoverConfig{})
+	assert.EqualError(t, err, "no endpoints provided")
+
+	_, err = NewFailover(FailoverConfig{
+		Endpoints:      []string{DevnetRPCEndpoint},
+		WriteEndpoints: []string{MainnetRPCEndpoint},
+	})
+	assert.EqualError(t, err, fmt.Sprintf("write endpoint %v is not in endpoints", MainnetRPCEndpoint))
+}
+
+func TestFailoverRecovery(t *testing.T) {
+	first := newFakeNode(1000)
+	defer first.server.Close()
+	second := newFakeNode(990)
+	defer second.server.Close()
+
+	f, err := NewFailover(FailoverConfig{
+		Endpoints:           []string{first.server.URL, second.server.URL},
+		HealthCheckInterval: time.Hour,
+	})
+	assert.Nil(t, err)
+	f.CheckHealth(context.Background())
+
+	first.mu.Lock()
+	first.down = true
+	first.mu.Unlock()
+
+	c := f.RpcClient()
+	_, err = c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, f.Statuses()[0].Failures)
+
+	first.mu.Lock()
+	first.down = false
+	first.mu.Unlock()
+	f.CheckHealth(context.Background())
+
+	statuses := f.Statuses()
+	assert.True(t, statuses[0].Healthy)
+	assert.Equal(t, 0, statuses[0].Failures)
+	assert.Nil(t, statuses[0].LastError)
+
+	// the recovered endpoint is preferred again
+	_, err = c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, first.count("getBalance"))
+	assert.Equal(t, 1, second.count("getBalance"))
+}
+
+func TestFailoverCandidatesOrder(t *testing.T) {
+	f, err := NewFailover(FailoverConfig{
+		Endpoints: []string{"http://slow", "http://a", "http://b"},
+	})
+	assert.Nil(t, err)
+	f.endpoints[0].status.Latency = 200 * time.Millisecond
+	f.endpoints[1].status.Latency = 30 * time.Millisecond
+	f.endpoints[2].status.Latency = 10 * time.Millisecond
+
+	// a and b are close, they keep the configured order
+	var endpoints []string
+	for _, e := range f.candidates(false) {
+		endpoints = append(endpoints, e.status.Endpoint)
+	}
+	assert.Equal(t, []string{"http://a", "http://b", "http://slow"}, endpoints)
+}
+
+func TestFailoverNotRetryable(t *testing.T) {
+	first := newFakeNode(1000)
+	defer first.server.Close()
+	second := newFakeNode(1000)
+	defer second.server.Close()
+
+	f, err := NewFailover(FailoverConfig{
+		Endpoints:           []string{first.server.URL, second.server.URL},
+		HealthCheckInterval: time.Hour,
+	})
+	assert.Nil(t, err)
+	f.CheckHealth(context.Background())
+
+	first.mu.Lock()
+	first.statusCode = http.StatusBadRequest
+	first.mu.Unlock()
+
+	// a rejected request is not sent to the other endpoints and doesn't count as a failure
+	c := f.RpcClient()
+	_, err = c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, first.count("getBalance"))
+	assert.Equal(t, 0, second.count("getBalance"))
+	assert.Equal(t, 0, f.Statuses()[0].Failures)
+}
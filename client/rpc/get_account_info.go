@@ -63,3 +63,23 @@ func (c *RpcClient) processGetAccountInfo(body []byte, rpcErr error) (res GetAcc
 	err = c.processRpcCall(body, rpcErr, &res)
 	return
 }
+
+// GetAccountInfo adds `getAccountInfo` to the batch
+func (b *Batch) GetAccountInfo(base58Addr string) *GetAccountInfoResponse {
+	res := new(GetAccountInfoResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetAccountInfo(body, rpcErr)
+		return
+	}, "getAccountInfo", base58Addr)
+	return res
+}
+
+// GetAccountInfoWithCfg adds `getAccountInfo` to the batch
+func (b *Batch) GetAccountInfoWithCfg(base58Addr string, cfg GetAccountInfoConfig) *GetAccountInfoResponse {
+	res := new(GetAccountInfoResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetAccountInfo(body, rpcErr)
+		return
+	}, "getAccountInfo", base58Addr, cfg)
+	return res
+}
@@ -35,3 +35,23 @@ func (c *RpcClient) processGetBalance(body []byte, rpcErr error) (res GetBalance
 	err = c.processRpcCall(body, rpcErr, &res)
 	return
 }
+
+// GetBalance adds `getBalance` to the batch
+func (b *Batch) GetBalance(base58Addr string) *GetBalanceResponse {
+	res := new(GetBalanceResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetBalance(body, rpcErr)
+		return
+	}, "getBalance", base58Addr)
+	return res
+}
+
+// GetBalanceWithCfg adds `getBalance` to the batch
+func (b *Batch) GetBalanceWithCfg(base58Addr string, cfg GetBalanceConfig) *GetBalanceResponse {
+	res := new(GetBalanceResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetBalance(body, rpcErr)
+		return
+	}, "getBalance", base58Addr, cfg)
+	return res
+}
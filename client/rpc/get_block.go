@@ -3,27 +3,25 @@ package rpc
 import "context"
 
 type GetBlockConfig struct {
-	// TODO custom
-	// Encoding           string     `json:"encoding"`             // default: "json", either "json", "jsonParsed", "base58" (slow), "base64"
-	// TransactionDetails string     `json:"transactionDetails"`   // default: "full", either "full", "signatures", "none"
-	Commitment Commitment `json:"commitment,omitempty"` // "processed" is not supported. If parameter not provided, the default is "finalized".
+	Encoding           TransactionEncoding `json:"encoding,omitempty"`           // default: "json"
+	TransactionDetails TransactionDetails  `json:"transactionDetails,omitempty"` // default: "full"
+	Rewards            *bool               `json:"rewards,omitempty"`            // default: true
+	Commitment         Commitment          `json:"commitment,omitempty"`         // "processed" is not supported. If parameter not provided, the default is "finalized".
+	// MaxSupportedTransactionVersion must be set to 0 to get blocks with v0 transactions, otherwise the request fails
+	MaxSupportedTransactionVersion *uint8 `json:"maxSupportedTransactionVersion,omitempty"`
 }
 
 type GetBlockResponse struct {
-	Blockhash         string `json:"blockhash"`
-	PreviousBlockhash string `json:"previousBlockhash"`
-	ParentSLot        uint64 `json:"parentSlot"`
-	BlockTime         int64  `json:"blockTime"`
-	Transactions      []struct {
-		Meta        TransactionMeta `json:"meta"`
-		Transaction Transaction     `json:"transaction"`
-	} `json:"transactions"`
-	Rewards []struct {
-		Pubkey      string `json:"pubkey"`
-		Lamports    int64  `json:"lamports"`
-		PostBalance uint64 `json:"postBalance"`
-		RewardType  string `json:"rewardType"` // type of reward: "fee", "rent", "voting", "staking"
-	} `json:"rewards"`
+	Blockhash         string  `json:"blockhash"`
+	PreviousBlockhash string  `json:"previousBlockhash"`
+	ParentSLot        uint64  `json:"parentSlot"`
+	BlockTime         int64   `json:"blockTime"`
+	BlockHeight       *uint64 `json:"blockHeight"`
+	// Transactions is set with the "full" and "accounts" transaction details
+	Transactions []TransactionWithMeta `json:"transactions"`
+	// Signatures is set with the "signatures" transaction details
+	Signatures []string `json:"signatures"`
+	Rewards    []Reward `json:"rewards"`
 }
 
 // NEW: This method is only available in solana-core v1.7 or newer. Please use getConfirmedBlock for solana-core v1.6
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetBlockCommitmentResponse struct {
 	Commitment []uint64 `json:"commitment"`
@@ -20,8 +17,5 @@ func (s *RpcClient) GetBlockCommitment(ctx context.Context, slot uint64) (GetBlo
 	if err != nil {
 		return GetBlockCommitmentResponse{}, err
 	}
-	if res.Error != nil {
-		return GetBlockCommitmentResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetBlockHeightConfig struct {
 	Commitment Commitment `json:"commitment,omitempty"`
@@ -19,8 +16,5 @@ func (s *RpcClient) GetBlockHeight(ctx context.Context, cfg GetBlockHeightConfig
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != nil {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
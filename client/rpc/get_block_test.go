@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetBlock(t *testing.T) {
+	version := uint8(0)
+	rewards := false
+	legacy := TransactionVersionLegacy
+	blockHeight := uint64(89003)
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getBlock", "params":[33, {"encoding": "base64", "transactionDetails": "full", "rewards": false, "maxSupportedTransactionVersion": 0}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"blockHeight":89003,"blockTime":1631803928,"blockhash":"CnyzNPfYUkKtz7N7BbDQyhBpr2XZTZAZ5afuK2Lf2cN9","parentSlot":32,"previousBlockhash":"6oVrFh7yRzSaFTVDtz1MXBZbBxyXByoVu9sP4bEm3cmc","transactions":[{"meta":{"err":null,"fee":5000,"innerInstructions":[],"logMessages":[],"postBalances":[9,1],"postTokenBalances":[],"preBalances":[5009,1],"preTokenBalances":[],"rewards":[],"status":{"Ok":null}},"transaction":["AQID","base64"],"version":"legacy"}]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetBlock(
+					context.Background(),
+					33,
+					GetBlockConfig{
+						Encoding:                       TransactionEncodingBase64,
+						TransactionDetails:             TransactionDetailsFull,
+						Rewards:                        &rewards,
+						MaxSupportedTransactionVersion: &version,
+					},
+				)
+			},
+			ExpectedResponse: GetBlockResponse{
+				Blockhash:         "CnyzNPfYUkKtz7N7BbDQyhBpr2XZTZAZ5afuK2Lf2cN9",
+				PreviousBlockhash: "6oVrFh7yRzSaFTVDtz1MXBZbBxyXByoVu9sP4bEm3cmc",
+				ParentSLot:        32,
+				BlockTime:         1631803928,
+				BlockHeight:       &blockHeight,
+				Transactions: []TransactionWithMeta{
+					{
+						Meta: TransactionMeta{
+							Fee:               5000,
+							PreBalances:       []int64{5009, 1},
+							PostBalances:      []int64{9, 1},
+							PreTokenBalances:  []TransactionMetaTokenBalance{},
+							PostTokenBalances: []TransactionMetaTokenBalance{},
+							LogMessages:       []string{},
+							InnerInstructions: []struct {
+								Index        uint64        `json:"index"`
+								Instructions []Instruction `json:"instructions"`
+							}{},
+							Status:  map[string]interface{}{"Ok": nil},
+							Rewards: []Reward{},
+						},
+						Transaction: EncodedTransaction{
+							Data:     "AQID",
+							Encoding: TransactionEncodingBase64,
+						},
+						Version: &legacy,
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getBlock", "params":[33, {"transactionDetails": "signatures", "commitment": "confirmed"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"blockHeight":89003,"blockTime":1631803928,"blockhash":"CnyzNPfYUkKtz7N7BbDQyhBpr2XZTZAZ5afuK2Lf2cN9","parentSlot":32,"previousBlockhash":"6oVrFh7yRzSaFTVDtz1MXBZbBxyXByoVu9sP4bEm3cmc","rewards":[{"commission":null,"lamports":2500,"postBalance":499999840001,"pubkey":"9zkU8suQBdhZVax2DSGNAnyEhEzfEELvA25CJhy5uwnW","rewardType":"Fee"}],"signatures":["4mmJ6Y2bRCKnxdLSWrsi7D5kg7Gp5N2Xb4KgnCYu7EmSW1QJbByJyCUXz8MNFA2VwBAe2cxErVpXVUbbMy5JPYie"]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetBlock(
+					context.Background(),
+					33,
+					GetBlockConfig{
+						TransactionDetails: TransactionDetailsSignatures,
+						Commitment:         CommitmentConfirmed,
+					},
+				)
+			},
+			ExpectedResponse: GetBlockResponse{
+				Blockhash:         "CnyzNPfYUkKtz7N7BbDQyhBpr2XZTZAZ5afuK2Lf2cN9",
+				PreviousBlockhash: "6oVrFh7yRzSaFTVDtz1MXBZbBxyXByoVu9sP4bEm3cmc",
+				ParentSLot:        32,
+				BlockTime:         1631803928,
+				BlockHeight:       &blockHeight,
+				Signatures:        []string{"4mmJ6Y2bRCKnxdLSWrsi7D5kg7Gp5N2Xb4KgnCYu7EmSW1QJbByJyCUXz8MNFA2VwBAe2cxErVpXVUbbMy5JPYie"},
+				Rewards: []Reward{
+					{
+						Pubkey:      "9zkU8suQBdhZVax2DSGNAnyEhEzfEELvA25CJhy5uwnW",
+						Lamports:    2500,
+						PostBalance: 499999840001,
+						RewardType:  "Fee",
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // GetBlockTime returns the estimated production time of a block.
 func (s *RpcClient) GetBlockTime(ctx context.Context, slot uint64) (int64, error) {
@@ -15,8 +12,5 @@ func (s *RpcClient) GetBlockTime(ctx context.Context, slot uint64) (int64, error
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != nil {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetClusterNodesResponse struct {
 	FeatureSet   *uint64 `json:"featureSet"`
@@ -25,8 +22,5 @@ func (s *RpcClient) GetClusterNodes(ctx context.Context) ([]GetClusterNodesRespo
 	if err != nil {
 		return []GetClusterNodesResponse{}, err
 	}
-	if res.Error != nil {
-		return []GetClusterNodesResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetConfirmedSignaturesForAddress struct {
 	BlockTime *int64      `json:"blockTime"`
@@ -32,8 +29,5 @@ func (s *RpcClient) GetConfirmedSignaturesForAddress(ctx context.Context, base58
 	if err != nil {
 		return []GetConfirmedSignaturesForAddress{}, err
 	}
-	if res.Error != nil {
-		return []GetConfirmedSignaturesForAddress{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
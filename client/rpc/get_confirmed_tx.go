@@ -3,9 +3,11 @@ package rpc
 import "context"
 
 type GetConfirmedTransactionResponse struct {
-	Slot        uint64          `json:"slot"`
-	Meta        TransactionMeta `json:"meta"`
-	Transaction Transaction     `json:"transaction"`
+	Slot        uint64              `json:"slot"`
+	BlockTime   *int64              `json:"blockTime"`
+	Meta        TransactionMeta     `json:"meta"`
+	Transaction EncodedTransaction  `json:"transaction"`
+	Version     *TransactionVersion `json:"version,omitempty"`
 }
 
 // DEPRECATED: Please use getTransaction instead This method is expected to be removed in solana-core v1.8
@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetFeeCalculatorForBlockhashResponse is full raw response of `getFeeCalculatorForBlockhash`
+type GetFeeCalculatorForBlockhashResponse struct {
+	GeneralResponse
+	Result GetFeeCalculatorForBlockhashResult `json:"result"`
+}
+
+// GetFeeCalculatorForBlockhashResult is part of response of `getFeeCalculatorForBlockhash`, Value is nil if the blockhash expired
+type GetFeeCalculatorForBlockhashResult struct {
+	Context Context                                  `json:"context"`
+	Value   *GetFeeCalculatorForBlockhashResultValue `json:"value"`
+}
+
+type GetFeeCalculatorForBlockhashResultValue struct {
+	FeeCalculator FeeCalculator `json:"feeCalculator"`
+}
+
+// DEPRECATED: Please use isBlockhashValid or getFeeForMessage instead. It is removed from solana-core v1.10
+// GetFeeCalculatorForBlockhash returns the fee calculator associated with the query blockhash, or null if the blockhash has expired
+func (c *RpcClient) GetFeeCalculatorForBlockhash(ctx context.Context, blockhash string) (GetFeeCalculatorForBlockhashResponse, error) {
+	return c.processGetFeeCalculatorForBlockhash(c.Call(ctx, "getFeeCalculatorForBlockhash", blockhash))
+}
+
+func (c *RpcClient) processGetFeeCalculatorForBlockhash(body []byte, rpcErr error) (res GetFeeCalculatorForBlockhashResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
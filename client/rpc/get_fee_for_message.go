@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetFeeForMessageResponse is full raw response of `getFeeForMessage`
+type GetFeeForMessageResponse struct {
+	GeneralResponse
+	Result GetFeeForMessageResult `json:"result"`
+}
+
+// GetFeeForMessageResult is part of response of `getFeeForMessage`, Value is nil if the blockhash of the message expired
+type GetFeeForMessageResult struct {
+	Context Context `json:"context"`
+	Value   *uint64 `json:"value"`
+}
+
+// GetFeeForMessageConfig is a option config for `getFeeForMessage`
+type GetFeeForMessageConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
+
+// GetFeeForMessage returns the fee the network will charge for a base64 encoded message
+// NEW: This method is only available in solana-core v1.9 or newer.
+func (c *RpcClient) GetFeeForMessage(ctx context.Context, base64Message string) (GetFeeForMessageResponse, error) {
+	return c.processGetFeeForMessage(c.Call(ctx, "getFeeForMessage", base64Message))
+}
+
+// GetFeeForMessageWithConfig returns the fee the network will charge for a base64 encoded message
+// NEW: This method is only available in solana-core v1.9 or newer.
+func (c *RpcClient) GetFeeForMessageWithConfig(ctx context.Context, base64Message string, cfg GetFeeForMessageConfig) (GetFeeForMessageResponse, error) {
+	return c.processGetFeeForMessage(c.Call(ctx, "getFeeForMessage", base64Message, cfg))
+}
+
+func (c *RpcClient) processGetFeeForMessage(body []byte, rpcErr error) (res GetFeeForMessageResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
+
+// GetFeeForMessage adds `getFeeForMessage` to the batch
+func (b *Batch) GetFeeForMessage(base64Message string) *GetFeeForMessageResponse {
+	res := new(GetFeeForMessageResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetFeeForMessage(body, rpcErr)
+		return
+	}, "getFeeForMessage", base64Message)
+	return res
+}
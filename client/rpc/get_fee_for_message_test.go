@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetFeeForMessage(t *testing.T) {
+	fee := uint64(5000)
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getFeeForMessage", "params":["AQABAgIAAQMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":5068},"value":5000},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetFeeForMessage(context.Background(), "AQABAgIAAQMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA")
+			},
+			ExpectedResponse: GetFeeForMessageResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetFeeForMessageResult{
+					Context: Context{
+						Slot: 5068,
+					},
+					Value: &fee,
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getFeeForMessage", "params":["AQABAgIAAQMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", {"commitment": "confirmed"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":5069},"value":null},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetFeeForMessageWithConfig(context.Background(), "AQABAgIAAQMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", GetFeeForMessageConfig{Commitment: CommitmentConfirmed})
+			},
+			ExpectedResponse: GetFeeForMessageResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetFeeForMessageResult{
+					Context: Context{
+						Slot: 5069,
+					},
+					Value: nil,
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // GetFirstAvailableBlock returns the slot of the lowest confirmed block that has not been purged from the ledger
 func (s *RpcClient) GetFirstAvailableBlock(ctx context.Context) (uint64, error) {
@@ -15,8 +12,5 @@ func (s *RpcClient) GetFirstAvailableBlock(ctx context.Context) (uint64, error)
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != nil {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
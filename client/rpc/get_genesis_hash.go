@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // GetGenesisHash returns the genesis hash
 func (s *RpcClient) GetGenesisHash(ctx context.Context) (string, error) {
@@ -15,8 +12,5 @@ func (s *RpcClient) GetGenesisHash(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if res.Error != nil {
-		return "", errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
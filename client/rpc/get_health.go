@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetHealthResponse is a full raw rpc response of `getHealth`
+type GetHealthResponse struct {
+	GeneralResponse
+	Result string `json:"result"`
+}
+
+// GetHealth returns the current health of the node. Result is "ok" if healthy, otherwise an error is in the response.
+func (c *RpcClient) GetHealth(ctx context.Context) (GetHealthResponse, error) {
+	return c.processGetHealth(c.Call(ctx, "getHealth"))
+}
+
+func (c *RpcClient) processGetHealth(body []byte, rpcErr error) (res GetHealthResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
+
+// GetHealth adds `getHealth` to the batch
+func (b *Batch) GetHealth() *GetHealthResponse {
+	res := new(GetHealthResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetHealth(body, rpcErr)
+		return
+	}, "getHealth")
+	return res
+}
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // GetIdentity returns the identity pubkey for the current node
 func (s *RpcClient) GetIdentity(ctx context.Context) (string, error) {
@@ -17,8 +14,5 @@ func (s *RpcClient) GetIdentity(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if res.Error != nil {
-		return "", errors.New(res.Error.Message)
-	}
 	return res.Result.Identity, nil
 }
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetLatestBlockhashResponse is full raw response of `getLatestBlockhash`
+type GetLatestBlockhashResponse struct {
+	GeneralResponse
+	Result GetLatestBlockhashResult `json:"result"`
+}
+
+// GetLatestBlockhashResult is part of response of `getLatestBlockhash`
+type GetLatestBlockhashResult struct {
+	Context Context                       `json:"context"`
+	Value   GetLatestBlockhashResultValue `json:"value"`
+}
+
+// GetLatestBlockhashResultValue is part of response of `getLatestBlockhash`
+type GetLatestBlockhashResultValue struct {
+	Blockhash string `json:"blockhash"`
+	// LastValidBlockHeight is the last block height at which the blockhash will be valid
+	LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+}
+
+// GetLatestBlockhashConfig is a option config for `getLatestBlockhash`
+type GetLatestBlockhashConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
+
+// GetLatestBlockhash returns the latest blockhash and the last block height at which it will be valid.
+// NEW: This method is only available in solana-core v1.9 or newer.
+func (c *RpcClient) GetLatestBlockhash(ctx context.Context) (GetLatestBlockhashResponse, error) {
+	return c.processGetLatestBlockhash(c.Call(ctx, "getLatestBlockhash"))
+}
+
+// GetLatestBlockhashWithConfig returns the latest blockhash and the last block height at which it will be valid.
+// NEW: This method is only available in solana-core v1.9 or newer.
+func (c *RpcClient) GetLatestBlockhashWithConfig(ctx context.Context, cfg GetLatestBlockhashConfig) (GetLatestBlockhashResponse, error) {
+	return c.processGetLatestBlockhash(c.Call(ctx, "getLatestBlockhash", cfg))
+}
+
+func (c *RpcClient) processGetLatestBlockhash(body []byte, rpcErr error) (res GetLatestBlockhashResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
+
+// GetLatestBlockhash adds `getLatestBlockhash` to the batch
+func (b *Batch) GetLatestBlockhash() *GetLatestBlockhashResponse {
+	res := new(GetLatestBlockhashResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetLatestBlockhash(body, rpcErr)
+		return
+	}, "getLatestBlockhash")
+	return res
+}
+
+// GetLatestBlockhashWithConfig adds `getLatestBlockhash` to the batch
+func (b *Batch) GetLatestBlockhashWithConfig(cfg GetLatestBlockhashConfig) *GetLatestBlockhashResponse {
+	res := new(GetLatestBlockhashResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetLatestBlockhash(body, rpcErr)
+		return
+	}, "getLatestBlockhash", cfg)
+	return res
+}
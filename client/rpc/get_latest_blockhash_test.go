@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetLatestBlockhash(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLatestBlockhash"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":2792},"value":{"blockhash":"EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N","lastValidBlockHeight":3090}},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetLatestBlockhash(context.Background())
+			},
+			ExpectedResponse: GetLatestBlockhashResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetLatestBlockhashResult{
+					Context: Context{
+						Slot: 2792,
+					},
+					Value: GetLatestBlockhashResultValue{
+						Blockhash:            "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N",
+						LastValidBlockHeight: 3090,
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLatestBlockhash", "params":[{"commitment": "confirmed"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":2793},"value":{"blockhash":"5nNRmBkGM7CwtD9LUtd3pjHe33viBVjdGA1coq2Lz22E","lastValidBlockHeight":3091}},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetLatestBlockhashWithConfig(context.Background(), GetLatestBlockhashConfig{Commitment: CommitmentConfirmed})
+			},
+			ExpectedResponse: GetLatestBlockhashResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetLatestBlockhashResult{
+					Context: Context{
+						Slot: 2793,
+					},
+					Value: GetLatestBlockhashResultValue{
+						Blockhash:            "5nNRmBkGM7CwtD9LUtd3pjHe33viBVjdGA1coq2Lz22E",
+						LastValidBlockHeight: 3091,
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // GetMinimumBalanceForRentExemption returns minimum balance required to make account rent exempt.
 func (s *RpcClient) GetMinimumBalanceForRentExemption(ctx context.Context, accountDataLen uint64) (uint64, error) {
@@ -15,8 +12,5 @@ func (s *RpcClient) GetMinimumBalanceForRentExemption(ctx context.Context, accou
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != nil {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
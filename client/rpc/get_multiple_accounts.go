@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetMultipleAccountsLimit is the max number of addresses the node accepts in one `getMultipleAccounts`
+const GetMultipleAccountsLimit = 100
+
+// GetMultipleAccountsConfig is an option config for `getMultipleAccounts`
+type GetMultipleAccountsConfig struct {
+	Commitment Commitment                     `json:"commitment,omitempty"`
+	Encoding   GetAccountInfoConfigEncoding   `json:"encoding,omitempty"`
+	DataSlice  *GetAccountInfoConfigDataSlice `json:"dataSlice,omitempty"`
+}
+
+// GetMultipleAccountsResponse is a full raw rpc response of `getMultipleAccounts`
+type GetMultipleAccountsResponse struct {
+	GeneralResponse
+	Result GetMultipleAccountsResult `json:"result"`
+}
+
+// GetMultipleAccountsResult is rpc result of `getMultipleAccounts`, Value is in the order of the addresses
+// and the entry of an account which doesn't exist is nil
+type GetMultipleAccountsResult struct {
+	Context Context                      `json:"context"`
+	Value   []*GetAccountInfoResultValue `json:"value"`
+}
+
+// GetMultipleAccounts returns the account information for a list of Pubkeys, at most GetMultipleAccountsLimit
+func (c *RpcClient) GetMultipleAccounts(ctx context.Context, base58Addrs []string) (GetMultipleAccountsResponse, error) {
+	return c.processGetMultipleAccounts(c.Call(ctx, "getMultipleAccounts", base58Addrs))
+}
+
+// GetMultipleAccountsWithCfg returns the account information for a list of Pubkeys, at most GetMultipleAccountsLimit
+func (c *RpcClient) GetMultipleAccountsWithCfg(ctx context.Context, base58Addrs []string, cfg GetMultipleAccountsConfig) (GetMultipleAccountsResponse, error) {
+	return c.processGetMultipleAccounts(c.Call(ctx, "getMultipleAccounts", base58Addrs, cfg))
+}
+
+func (c *RpcClient) processGetMultipleAccounts(body []byte, rpcErr error) (res GetMultipleAccountsResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
+
+// GetMultipleAccounts adds `getMultipleAccounts` to the batch
+func (b *Batch) GetMultipleAccounts(base58Addrs []string) *GetMultipleAccountsResponse {
+	res := new(GetMultipleAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetMultipleAccounts(body, rpcErr)
+		return
+	}, "getMultipleAccounts", base58Addrs)
+	return res
+}
+
+// GetMultipleAccountsWithCfg adds `getMultipleAccounts` to the batch
+func (b *Batch) GetMultipleAccountsWithCfg(base58Addrs []string, cfg GetMultipleAccountsConfig) *GetMultipleAccountsResponse {
+	res := new(GetMultipleAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetMultipleAccounts(body, rpcErr)
+		return
+	}, "getMultipleAccounts", base58Addrs, cfg)
+	return res
+}
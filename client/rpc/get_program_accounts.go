@@ -106,3 +106,23 @@ func (c *RpcClient) toInternalGetProgramAccountsConfig(cfg GetProgramAccountsCon
 		WithContext:              withContext,
 	}
 }
+
+// GetProgramAccounts adds `getProgramAccounts` to the batch
+func (b *Batch) GetProgramAccounts(programId string) *GetProgramAccountsResponse {
+	res := new(GetProgramAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetProgramAccounts(body, rpcErr)
+		return
+	}, "getProgramAccounts", programId)
+	return res
+}
+
+// GetProgramAccountsWithConfig adds `getProgramAccounts` to the batch
+func (b *Batch) GetProgramAccountsWithConfig(programId string, cfg GetProgramAccountsConfig) *GetProgramAccountsResponse {
+	res := new(GetProgramAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetProgramAccounts(body, rpcErr)
+		return
+	}, "getProgramAccounts", programId, b.client.toInternalGetProgramAccountsConfig(cfg, false))
+	return res
+}
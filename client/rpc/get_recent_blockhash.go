@@ -48,3 +48,23 @@ func (c *RpcClient) processGetRecentBlockhash(body []byte, rpcErr error) (res Ge
 	err = c.processRpcCall(body, rpcErr, &res)
 	return
 }
+
+// GetRecentBlockhash adds `getRecentBlockhash` to the batch
+func (b *Batch) GetRecentBlockhash() *GetRecentBlockHashResponse {
+	res := new(GetRecentBlockHashResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetRecentBlockhash(body, rpcErr)
+		return
+	}, "getRecentBlockhash")
+	return res
+}
+
+// GetRecentBlockhashWithConfig adds `getRecentBlockhash` to the batch
+func (b *Batch) GetRecentBlockhashWithConfig(cfg GetRecentBlockhashConfig) *GetRecentBlockHashResponse {
+	res := new(GetRecentBlockHashResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetRecentBlockhash(body, rpcErr)
+		return
+	}, "getRecentBlockhash", cfg)
+	return res
+}
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetSignatureStatusesResponse struct {
 	Slot               uint64      `json:"slot"`
@@ -24,8 +21,5 @@ func (s *RpcClient) GetSignatureStatuses(ctx context.Context, signatures []strin
 	if err != nil {
 		return nil, err
 	}
-	if res.Error != nil {
-		return nil, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
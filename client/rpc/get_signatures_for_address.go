@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetSignaturesForAddress struct {
 	Signature string      `json:"signature"`
@@ -32,8 +29,5 @@ func (s *RpcClient) GetSignaturesForAddress(ctx context.Context, base58Addr stri
 	if err != nil {
 		return []GetConfirmedSignaturesForAddress{}, err
 	}
-	if res.Error != nil {
-		return []GetConfirmedSignaturesForAddress{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
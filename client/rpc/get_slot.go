@@ -29,3 +29,23 @@ func (c *RpcClient) processGetSlot(body []byte, rpcErr error) (res GetSlotRespon
 	err = c.processRpcCall(body, rpcErr, &res)
 	return
 }
+
+// GetSlot adds `getSlot` to the batch
+func (b *Batch) GetSlot() *GetSlotResponse {
+	res := new(GetSlotResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetSlot(body, rpcErr)
+		return
+	}, "getSlot")
+	return res
+}
+
+// GetSlotWithCfg adds `getSlot` to the batch
+func (b *Batch) GetSlotWithCfg(cfg GetSlotConfig) *GetSlotResponse {
+	res := new(GetSlotResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetSlot(body, rpcErr)
+		return
+	}, "getSlot", cfg)
+	return res
+}
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type StakeActivationState string
 
@@ -36,8 +33,5 @@ func (s *RpcClient) GetStakeActivation(ctx context.Context, address string, cfg
 	if err != nil {
 		return GetStakeActivationResponse{}, err
 	}
-	if res.Error != nil {
-		return GetStakeActivationResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetTokenAccountsByDelegate returns all SPL Token accounts by approved delegate
+func (c *RpcClient) GetTokenAccountsByDelegate(ctx context.Context, base58Addr string, filter GetTokenAccountsFilter) (GetTokenAccountsResponse, error) {
+	return c.processGetTokenAccounts(c.Call(ctx, "getTokenAccountsByDelegate", base58Addr, filter))
+}
+
+// GetTokenAccountsByDelegateWithCfg returns all SPL Token accounts by approved delegate
+func (c *RpcClient) GetTokenAccountsByDelegateWithCfg(ctx context.Context, base58Addr string, filter GetTokenAccountsFilter, cfg GetTokenAccountsConfig) (GetTokenAccountsResponse, error) {
+	return c.processGetTokenAccounts(c.Call(ctx, "getTokenAccountsByDelegate", base58Addr, filter, cfg))
+}
+
+// GetTokenAccountsByDelegate adds `getTokenAccountsByDelegate` to the batch
+func (b *Batch) GetTokenAccountsByDelegate(base58Addr string, filter GetTokenAccountsFilter) *GetTokenAccountsResponse {
+	res := new(GetTokenAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetTokenAccounts(body, rpcErr)
+		return
+	}, "getTokenAccountsByDelegate", base58Addr, filter)
+	return res
+}
+
+// GetTokenAccountsByDelegateWithCfg adds `getTokenAccountsByDelegate` to the batch
+func (b *Batch) GetTokenAccountsByDelegateWithCfg(base58Addr string, filter GetTokenAccountsFilter, cfg GetTokenAccountsConfig) *GetTokenAccountsResponse {
+	res := new(GetTokenAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetTokenAccounts(body, rpcErr)
+		return
+	}, "getTokenAccountsByDelegate", base58Addr, filter, cfg)
+	return res
+}
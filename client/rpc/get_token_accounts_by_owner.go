@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"context"
+)
+
+type GetTokenAccountsConfigEncoding string
+
+const (
+	// GetTokenAccountsConfigEncodingBase58 limited to Account data of less than 128 bytes
+	GetTokenAccountsConfigEncodingBase58     GetTokenAccountsConfigEncoding = "base58"
+	GetTokenAccountsConfigEncodingJsonParsed GetTokenAccountsConfigEncoding = "jsonParsed"
+	GetTokenAccountsConfigEncodingBase64     GetTokenAccountsConfigEncoding = "base64"
+	GetTokenAccountsConfigEncodingBase64Zstd GetTokenAccountsConfigEncoding = "base64+zstd"
+)
+
+// GetTokenAccountsFilter selects token accounts by either Mint or ProgramId, not both
+type GetTokenAccountsFilter struct {
+	Mint      string `json:"mint,omitempty"`
+	ProgramId string `json:"programId,omitempty"`
+}
+
+// GetTokenAccountsConfig is an option config for `getTokenAccountsByOwner` and `getTokenAccountsByDelegate`
+type GetTokenAccountsConfig struct {
+	Commitment Commitment                     `json:"commitment,omitempty"`
+	Encoding   GetTokenAccountsConfigEncoding `json:"encoding,omitempty"`
+	DataSlice  *GetAccountInfoConfigDataSlice `json:"dataSlice,omitempty"`
+}
+
+// GetTokenAccountsResponse is a full raw rpc response of `getTokenAccountsByOwner` and `getTokenAccountsByDelegate`
+type GetTokenAccountsResponse struct {
+	GeneralResponse
+	Result GetTokenAccountsResult `json:"result"`
+}
+
+// GetTokenAccountsResult is rpc result of `getTokenAccountsByOwner` and `getTokenAccountsByDelegate`.
+// Data of an account is a map if it was fetched in jsonParsed encoding.
+type GetTokenAccountsResult struct {
+	Context Context              `json:"context"`
+	Value   []GetProgramAccounts `json:"value"`
+}
+
+// GetTokenAccountsByOwner returns all SPL Token accounts by token owner
+func (c *RpcClient) GetTokenAccountsByOwner(ctx context.Context, base58Addr string, filter GetTokenAccountsFilter) (GetTokenAccountsResponse, error) {
+	return c.processGetTokenAccounts(c.Call(ctx, "getTokenAccountsByOwner", base58Addr, filter))
+}
+
+// GetTokenAccountsByOwnerWithCfg returns all SPL Token accounts by token owner
+func (c *RpcClient) GetTokenAccountsByOwnerWithCfg(ctx context.Context, base58Addr string, filter GetTokenAccountsFilter, cfg GetTokenAccountsConfig) (GetTokenAccountsResponse, error) {
+	return c.processGetTokenAccounts(c.Call(ctx, "getTokenAccountsByOwner", base58Addr, filter, cfg))
+}
+
+func (c *RpcClient) processGetTokenAccounts(body []byte, rpcErr error) (res GetTokenAccountsResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
+
+// GetTokenAccountsByOwner adds `getTokenAccountsByOwner` to the batch
+func (b *Batch) GetTokenAccountsByOwner(base58Addr string, filter GetTokenAccountsFilter) *GetTokenAccountsResponse {
+	res := new(GetTokenAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetTokenAccounts(body, rpcErr)
+		return
+	}, "getTokenAccountsByOwner", base58Addr, filter)
+	return res
+}
+
+// GetTokenAccountsByOwnerWithCfg adds `getTokenAccountsByOwner` to the batch
+func (b *Batch) GetTokenAccountsByOwnerWithCfg(base58Addr string, filter GetTokenAccountsFilter, cfg GetTokenAccountsConfig) *GetTokenAccountsResponse {
+	res := new(GetTokenAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetTokenAccounts(body, rpcErr)
+		return
+	}, "getTokenAccountsByOwner", base58Addr, filter, cfg)
+	return res
+}
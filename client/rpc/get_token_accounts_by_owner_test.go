@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetTokenAccountsByOwner(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenAccountsByOwner", "params":["27kVX7JpPZ1bsrSckbR76mV6GeRqtrjoddubfg2zBpHZ", {"programId": "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}, {"encoding": "base64"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":80218681},"value":[{"account":{"data":["BqxDMvAE3z8v4GA6nKb4emt62Hwb6zM8T7X9V+nGgdTXCdN1XSzcXJQxHwZpHwLiJ3txd6d7zkyUx+/jj/NhtmQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":185},"pubkey":"AyHWro8zumyZN68Mfu53gdr5ctZHYgfVJCo3h2nRHtMt"}]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetTokenAccountsByOwnerWithCfg(
+					context.Background(),
+					"27kVX7JpPZ1bsrSckbR76mV6GeRqtrjoddubfg2zBpHZ",
+					GetTokenAccountsFilter{
+						ProgramId: "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+					},
+					GetTokenAccountsConfig{
+						Encoding: GetTokenAccountsConfigEncodingBase64,
+					},
+				)
+			},
+			ExpectedResponse: GetTokenAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetTokenAccountsResult{
+					Context: Context{
+						Slot: 80218681,
+					},
+					Value: []GetProgramAccounts{
+						{
+							Pubkey: "AyHWro8zumyZN68Mfu53gdr5ctZHYgfVJCo3h2nRHtMt",
+							Account: GetProgramAccountsAccount{
+								Lamports:   2039280,
+								Owner:      "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+								RentEpoch:  185,
+								Data:       []interface{}{"BqxDMvAE3z8v4GA6nKb4emt62Hwb6zM8T7X9V+nGgdTXCdN1XSzcXJQxHwZpHwLiJ3txd6d7zkyUx+/jj/NhtmQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", "base64"},
+								Executable: false,
+							},
+						},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenAccountsByOwner", "params":["27kVX7JpPZ1bsrSckbR76mV6GeRqtrjoddubfg2zBpHZ", {"mint": "Gh9ZwEmdLJ8DscKNTkTqPbNwLNNBjuSzaG9Vp2KGtKJr"}, {"encoding": "jsonParsed", "commitment": "confirmed"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":80218681},"value":[{"account":{"data":{"parsed":{"info":{"isNative":false,"mint":"Gh9ZwEmdLJ8DscKNTkTqPbNwLNNBjuSzaG9Vp2KGtKJr","owner":"27kVX7JpPZ1bsrSckbR76mV6GeRqtrjoddubfg2zBpHZ","state":"initialized","tokenAmount":{"amount":"100","decimals":0,"uiAmount":100.0,"uiAmountString":"100"}},"type":"account"},"program":"spl-token","space":165},"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":185},"pubkey":"AyHWro8zumyZN68Mfu53gdr5ctZHYgfVJCo3h2nRHtMt"}]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetTokenAccountsByOwnerWithCfg(
+					context.Background(),
+					"27kVX7JpPZ1bsrSckbR76mV6GeRqtrjoddubfg2zBpHZ",
+					GetTokenAccountsFilter{
+						Mint: "Gh9ZwEmdLJ8DscKNTkTqPbNwLNNBjuSzaG9Vp2KGtKJr",
+					},
+					GetTokenAccountsConfig{
+						Commitment: CommitmentConfirmed,
+						Encoding:   GetTokenAccountsConfigEncodingJsonParsed,
+					},
+				)
+			},
+			ExpectedResponse: GetTokenAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetTokenAccountsResult{
+					Context: Context{
+						Slot: 80218681,
+					},
+					Value: []GetProgramAccounts{
+						{
+							Pubkey: "AyHWro8zumyZN68Mfu53gdr5ctZHYgfVJCo3h2nRHtMt",
+							Account: GetProgramAccountsAccount{
+								Lamports:  2039280,
+								Owner:     "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+								RentEpoch: 185,
+								Data: map[string]interface{}{
+									"parsed": map[string]interface{}{
+										"info": map[string]interface{}{
+											"isNative": false,
+											"mint":     "Gh9ZwEmdLJ8DscKNTkTqPbNwLNNBjuSzaG9Vp2KGtKJr",
+											"owner":    "27kVX7JpPZ1bsrSckbR76mV6GeRqtrjoddubfg2zBpHZ",
+											"state":    "initialized",
+											"tokenAmount": map[string]interface{}{
+												"amount":         "100",
+												"decimals":       float64(0),
+												"uiAmount":       float64(100),
+												"uiAmountString": "100",
+											},
+										},
+										"type": "account",
+									},
+									"program": "spl-token",
+									"space":   float64(165),
+								},
+								Executable: false,
+							},
+						},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
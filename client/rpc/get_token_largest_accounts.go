@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetTokenLargestAccountsConfig is an option config for `getTokenLargestAccounts`
+type GetTokenLargestAccountsConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
+
+// GetTokenLargestAccountsResponse is a full raw rpc response of `getTokenLargestAccounts`
+type GetTokenLargestAccountsResponse struct {
+	GeneralResponse
+	Result GetTokenLargestAccountsResult `json:"result"`
+}
+
+// GetTokenLargestAccountsResult is rpc result of `getTokenLargestAccounts`
+type GetTokenLargestAccountsResult struct {
+	Context Context                              `json:"context"`
+	Value   []GetTokenLargestAccountsResultValue `json:"value"`
+}
+
+// GetTokenLargestAccountsResultValue is a token account and its balance
+type GetTokenLargestAccountsResultValue struct {
+	Address        string   `json:"address"`
+	Amount         string   `json:"amount"`
+	Decimals       int64    `json:"decimals"`
+	UIAmount       *float64 `json:"uiAmount"`
+	UIAmountString string   `json:"uiAmountString"`
+}
+
+// GetTokenLargestAccounts returns the 20 largest accounts of a particular SPL Token type
+func (c *RpcClient) GetTokenLargestAccounts(ctx context.Context, mintBase58Addr string) (GetTokenLargestAccountsResponse, error) {
+	return c.processGetTokenLargestAccounts(c.Call(ctx, "getTokenLargestAccounts", mintBase58Addr))
+}
+
+// GetTokenLargestAccountsWithCfg returns the 20 largest accounts of a particular SPL Token type
+func (c *RpcClient) GetTokenLargestAccountsWithCfg(ctx context.Context, mintBase58Addr string, cfg GetTokenLargestAccountsConfig) (GetTokenLargestAccountsResponse, error) {
+	return c.processGetTokenLargestAccounts(c.Call(ctx, "getTokenLargestAccounts", mintBase58Addr, cfg))
+}
+
+func (c *RpcClient) processGetTokenLargestAccounts(body []byte, rpcErr error) (res GetTokenLargestAccountsResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
+
+// GetTokenLargestAccounts adds `getTokenLargestAccounts` to the batch
+func (b *Batch) GetTokenLargestAccounts(mintBase58Addr string) *GetTokenLargestAccountsResponse {
+	res := new(GetTokenLargestAccountsResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processGetTokenLargestAccounts(body, rpcErr)
+		return
+	}, "getTokenLargestAccounts", mintBase58Addr)
+	return res
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetTokenLargestAccounts(t *testing.T) {
+	uiAmount1, uiAmount2 := 7.71, 771.0
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenLargestAccounts", "params":["3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E"]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1114},"value":[{"address":"FYjHNoFtSQ5uijKrZFyYAxvEr87hsKXkXcxkcmkBAf4r","amount":"771","decimals":2,"uiAmount":7.71,"uiAmountString":"7.71"},{"address":"BnsywxTcaYeNUtzrPxQUvzAWxfzZe3ZLUJ4wMMuLESnu","amount":"77100","decimals":2,"uiAmount":771,"uiAmountString":"771"}]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetTokenLargestAccounts(
+					context.Background(),
+					"3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E",
+				)
+			},
+			ExpectedResponse: GetTokenLargestAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetTokenLargestAccountsResult{
+					Context: Context{
+						Slot: 1114,
+					},
+					Value: []GetTokenLargestAccountsResultValue{
+						{
+							Address:        "FYjHNoFtSQ5uijKrZFyYAxvEr87hsKXkXcxkcmkBAf4r",
+							Amount:         "771",
+							Decimals:       2,
+							UIAmount:       &uiAmount1,
+							UIAmountString: "7.71",
+						},
+						{
+							Address:        "BnsywxTcaYeNUtzrPxQUvzAWxfzZe3ZLUJ4wMMuLESnu",
+							Amount:         "77100",
+							Decimals:       2,
+							UIAmount:       &uiAmount2,
+							UIAmountString: "771",
+						},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenLargestAccounts", "params":["3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E", {"commitment": "finalized"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1114},"value":[]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetTokenLargestAccountsWithCfg(
+					context.Background(),
+					"3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E",
+					GetTokenLargestAccountsConfig{
+						Commitment: CommitmentFinalized,
+					},
+				)
+			},
+			ExpectedResponse: GetTokenLargestAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetTokenLargestAccountsResult{
+					Context: Context{
+						Slot: 1114,
+					},
+					Value: []GetTokenLargestAccountsResultValue{},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
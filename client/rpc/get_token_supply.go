@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetTokenSupply struct {
 	Amount         string `json:"amount"`
@@ -24,8 +21,5 @@ func (s *RpcClient) GetTokenSupply(ctx context.Context, mintBase58Addr string, c
 	if err != nil {
 		return GetTokenSupply{}, err
 	}
-	if res.Error != nil {
-		return GetTokenSupply{}, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
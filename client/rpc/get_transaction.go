@@ -3,15 +3,16 @@ package rpc
 import "context"
 
 type GetTransactionWithLimitConfig struct {
-	// TODO custom encoding
-	// Encoding   string     `json:"encoding"`          // either "json", "jsonParsed", "base58" (slow), "base64", default: json
-	Commitment Commitment `json:"commitment,omitempty"` // "processed" is not supported. If parameter not provided, the default is "finalized".
+	Encoding   TransactionEncoding `json:"encoding,omitempty"`   // default: "json"
+	Commitment Commitment          `json:"commitment,omitempty"` // "processed" is not supported. If parameter not provided, the default is "finalized".
+	// MaxSupportedTransactionVersion must be set to 0 to get v0 transactions, otherwise the request fails
+	MaxSupportedTransactionVersion *uint8 `json:"maxSupportedTransactionVersion,omitempty"`
 }
 
 type GetTransaction struct {
-	Slot        uint64          `json:"slot"`
-	Meta        TransactionMeta `json:"meta"`
-	Transaction Transaction     `json:"transaction"`
+	Slot        uint64             `json:"slot"`
+	Meta        TransactionMeta    `json:"meta"`
+	Transaction EncodedTransaction `json:"transaction"`
 }
 
 // NEW: This method is only available in solana-core v1.7 or newer. Please use getConfirmedTransaction for solana-core v1.6
@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // GetTransactionCount returns the current transaction count from the ledger
 func (s *RpcClient) GetTransactionCount(ctx context.Context) (uint64, error) {
@@ -15,8 +12,5 @@ func (s *RpcClient) GetTransactionCount(ctx context.Context) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != nil {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
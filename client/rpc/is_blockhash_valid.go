@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+)
+
+// IsBlockhashValidResponse is full raw response of `isBlockhashValid`
+type IsBlockhashValidResponse struct {
+	GeneralResponse
+	Result IsBlockhashValidResult `json:"result"`
+}
+
+// IsBlockhashValidResult is part of response of `isBlockhashValid`
+type IsBlockhashValidResult struct {
+	Context Context `json:"context"`
+	Value   bool    `json:"value"`
+}
+
+// IsBlockhashValidConfig is a option config for `isBlockhashValid`
+type IsBlockhashValidConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
+
+// IsBlockhashValid returns whether a blockhash is still valid or not
+// NEW: This method is only available in solana-core v1.9 or newer.
+func (c *RpcClient) IsBlockhashValid(ctx context.Context, blockhash string) (IsBlockhashValidResponse, error) {
+	return c.processIsBlockhashValid(c.Call(ctx, "isBlockhashValid", blockhash))
+}
+
+// IsBlockhashValidWithConfig returns whether a blockhash is still valid or not
+// NEW: This method is only available in solana-core v1.9 or newer.
+func (c *RpcClient) IsBlockhashValidWithConfig(ctx context.Context, blockhash string, cfg IsBlockhashValidConfig) (IsBlockhashValidResponse, error) {
+	return c.processIsBlockhashValid(c.Call(ctx, "isBlockhashValid", blockhash, cfg))
+}
+
+func (c *RpcClient) processIsBlockhashValid(body []byte, rpcErr error) (res IsBlockhashValidResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
+
+// IsBlockhashValid adds `isBlockhashValid` to the batch
+func (b *Batch) IsBlockhashValid(blockhash string) *IsBlockhashValidResponse {
+	res := new(IsBlockhashValidResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processIsBlockhashValid(body, rpcErr)
+		return
+	}, "isBlockhashValid", blockhash)
+	return res
+}
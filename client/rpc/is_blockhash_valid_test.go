@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIsBlockhashValid(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"isBlockhashValid", "params":["J7rBdM6AecPDEZp8aPq5iPSNKVkU5Q76F3oAV4eW5wsW"]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":2483},"value":false},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.IsBlockhashValid(context.Background(), "J7rBdM6AecPDEZp8aPq5iPSNKVkU5Q76F3oAV4eW5wsW")
+			},
+			ExpectedResponse: IsBlockhashValidResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: IsBlockhashValidResult{
+					Context: Context{
+						Slot: 2483,
+					},
+					Value: false,
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"isBlockhashValid", "params":["J7rBdM6AecPDEZp8aPq5iPSNKVkU5Q76F3oAV4eW5wsW", {"commitment": "processed"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":2484},"value":true},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.IsBlockhashValidWithConfig(context.Background(), "J7rBdM6AecPDEZp8aPq5iPSNKVkU5Q76F3oAV4eW5wsW", IsBlockhashValidConfig{Commitment: CommitmentProcessed})
+			},
+			ExpectedResponse: IsBlockhashValidResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: IsBlockhashValidResult{
+					Context: Context{
+						Slot: 2484,
+					},
+					Value: true,
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Request is a json rpc request passing through the middlewares
+type Request struct {
+	// Methods has the method of every call, a single one unless the request is a batch
+	Methods []string
+	// Body is the json payload
+	Body []byte
+	// Header is added to the http request, on top of the headers of the options
+	Header http.Header
+}
+
+// Response is the http response of a Request. It is nil if no response was received.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Handler sends a request. The error is returned for network errors and http status codes beyond 200~300,
+// a json rpc error is in the body.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a handler, e.g. for logging, metrics, tracing or modifying requests and responses.
+// Every rpc method goes through the middlewares once, retries and failover happen inside.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middlewares, the first one is the outermost
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *RpcClient) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// Logger is satisfied by *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// LoggingMiddleware logs the methods, the duration and the error of every request
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			res, err := next(ctx, req)
+			if err != nil {
+				logger.Printf("rpc: %v failed in %v, err: %v", req.Methods, time.Since(start), err)
+				return res, err
+			}
+			logger.Printf("rpc: %v done in %v", req.Methods, time.Since(start))
+			return res, err
+		}
+	}
+}
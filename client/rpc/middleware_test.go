@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "span-1", req.Header.Get("X-Trace-Id"))
+		rw.Write([]byte(`{"jsonrpc":"2.0","result":{"absoluteSlot":166598,"blockHeight":166500,"epoch":27,"slotIndex":2790,"slotsInEpoch":8192},"id":1}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				calls = append(calls, fmt.Sprintf("%v before %v", name, req.Methods))
+				res, err := next(ctx, req)
+				calls = append(calls, fmt.Sprintf("%v after %v", name, res.StatusCode))
+				return res, err
+			}
+		}
+	}
+	trace := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("X-Trace-Id", "span-1")
+			return next(ctx, req)
+		}
+	}
+	var logs bytes.Buffer
+
+	c := NewRpcClient(server.URL, WithMiddleware(record("outer"), record("inner"), trace, LoggingMiddleware(log.New(&logs, "", 0))))
+	res, err := c.GetEpochInfo(context.Background(), CommitmentFinalized)
+	assert.Nil(t, err)
+	assert.Equal(t, GetEpochInfoResponse{
+		AbsoluteSlot: 166598,
+		BlockHeight:  166500,
+		Epoch:        27,
+		SlotIndex:    2790,
+		SlotsInEpoch: 8192,
+	}, res)
+	assert.Equal(t, []string{
+		"outer before [getEpochInfo]",
+		"inner before [getEpochInfo]",
+		"inner after 200",
+		"outer after 200",
+	}, calls)
+	assert.Contains(t, logs.String(), "rpc: [getEpochInfo] done in")
+}
+
+func TestMiddlewareMutateResponse(t *testing.T) {
+	stub := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			return &Response{
+				StatusCode: http.StatusOK,
+				Body:       []byte(`{"jsonrpc":"2.0","result":1,"id":1}`),
+			}, nil
+		}
+	}
+	c := NewRpcClient("http://127.0.0.1:0", WithMiddleware(stub))
+	res, err := c.GetSlot(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), res.Result)
+
+	height, err := c.GetBlockHeight(context.Background(), GetBlockHeightConfig{})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), height)
+}
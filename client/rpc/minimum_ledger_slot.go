@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // MinimumLedgerSlot returns the lowest slot that the node has information about in its ledger.
 // This value may increase over time if the node is configured to purge older ledger data
@@ -16,8 +13,5 @@ func (s *RpcClient) MinimumLedgerSlot(ctx context.Context) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != nil {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
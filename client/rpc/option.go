@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultTimeout             = 60 * time.Second
+	defaultMaxIdleConnsPerHost = 32
+)
+
+// Option customizes the RpcClient
+type Option func(*RpcClient)
+
+// WithHTTPClient uses the caller's http client for every request. It is not modified by the other options.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *RpcClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport replaces the transport of the http client, e.g. to use a proxy or a tuned connection pool
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *RpcClient) {
+		c.transport = transport
+	}
+}
+
+// WithTimeout sets the timeout of every http request. default: DefaultTimeout, 0 means no timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *RpcClient) {
+		c.timeout = &timeout
+	}
+}
+
+// WithHeader adds a static header to every request, e.g. an api key of a rpc provider
+func WithHeader(key, value string) Option {
+	return func(c *RpcClient) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithHeaderFunc sets headers per request, e.g. a bearer token which should be refreshed.
+// An error aborts the request.
+func WithHeaderFunc(f func(ctx context.Context, header http.Header) error) Option {
+	return func(c *RpcClient) {
+		c.headerFuncs = append(c.headerFuncs, f)
+	}
+}
+
+// WithGzip asks the node for gzip compressed responses
+func WithGzip() Option {
+	return func(c *RpcClient) {
+		c.gzip = true
+	}
+}
+
+func (c *RpcClient) buildHTTPClient() {
+	var httpClient http.Client
+	if c.httpClient != nil {
+		httpClient = *c.httpClient
+	} else {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+		httpClient = http.Client{
+			Transport: transport,
+			Timeout:   DefaultTimeout,
+		}
+	}
+	if c.transport != nil {
+		httpClient.Transport = c.transport
+	}
+	if c.timeout != nil {
+		httpClient.Timeout = *c.timeout
+	}
+	c.httpClient = &httpClient
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "my-api-key", req.Header.Get("X-Api-Key"))
+		assert.Equal(t, "Bearer token-1", req.Header.Get("Authorization"))
+		rw.Write([]byte(`{"jsonrpc":"2.0","result":1,"id":1}`))
+	}))
+	defer server.Close()
+
+	c := NewRpcClient(
+		server.URL,
+		WithHeader("X-Api-Key", "my-api-key"),
+		WithHeaderFunc(func(ctx context.Context, header http.Header) error {
+			header.Set("Authorization", "Bearer token-1")
+			return nil
+		}),
+	)
+	res, err := c.GetSlot(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), res.Result)
+}
+
+func TestWithHeaderFuncConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, []string{"a", "b", "c", "d"}, req.Header.Values("X-Tag"))
+		rw.Write([]byte(`{"jsonrpc":"2.0","result":1,"id":1}`))
+	}))
+	defer server.Close()
+
+	c := NewRpcClient(
+		server.URL,
+		WithHeader("X-Tag", "a"),
+		WithHeader("X-Tag", "b"),
+		WithHeader("X-Tag", "c"),
+		WithHeaderFunc(func(ctx context.Context, header http.Header) error {
+			// the values of the client have room left, an append must not write into them
+			header.Add("X-Tag", "d")
+			return nil
+		}),
+	)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Call(context.Background(), "getSlot")
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithHeaderFuncError(t *testing.T) {
+	c := NewRpcClient(
+		"http://127.0.0.1:0",
+		WithHeaderFunc(func(ctx context.Context, header http.Header) error {
+			return errors.New("token expired")
+		}),
+	)
+	_, err := c.Call(context.Background(), "getSlot")
+	assert.EqualError(t, err, "failed to set header, err: token expired")
+}
+
+func TestWithGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "gzip", req.Header.Get("Accept-Encoding"))
+		rw.Header().Set("Content-Encoding", "gzip")
+		w := gzip.NewWriter(rw)
+		w.Write([]byte(`{"jsonrpc":"2.0","result":77317717,"id":1}`))
+		w.Close()
+	}))
+	defer server.Close()
+
+	c := NewRpcClient(server.URL, WithGzip())
+	res, err := c.GetSlot(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(77317717), res.Result)
+}
+
+func TestWithTransport(t *testing.T) {
+	var called bool
+	c := NewRpcClient(
+		"http://localhost:8899",
+		WithHTTPClient(&http.Client{}),
+		WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			called = true
+			return nil, errors.New("no route")
+		})),
+		WithTimeout(time.Second),
+	)
+	assert.Equal(t, time.Second, c.httpClient.Timeout)
+	_, err := c.Call(context.Background(), "getSlot")
+	assert.NotNil(t, err)
+	assert.True(t, called)
+}
+
+func TestDefaultHTTPClient(t *testing.T) {
+	c := NewRpcClient(DevnetRPCEndpoint)
+	assert.Equal(t, DefaultTimeout, c.httpClient.Timeout)
+
+	// copies share the same connection pool
+	copied := c
+	assert.Same(t, c.httpClient, copied.httpClient)
+}
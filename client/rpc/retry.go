@@ -0,0 +1,203 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRetryMinBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 10 * time.Second
+)
+
+// DefaultRetryableErrorCodes are json rpc error codes caused by a node state which usually recovers soon
+var DefaultRetryableErrorCodes = []int{
+	-32004, // block not available for slot
+	-32005, // node is unhealthy / behind
+	-32014, // block status not yet available
+	-32016, // minimum context slot has not been reached
+	-32603, // internal error
+}
+
+// DefaultRetryableStatusCodes are http status codes of rate limits and transient server errors
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy retries failed requests with exponential backoff and jitter.
+// A Retry-After header takes precedence over the computed backoff.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// MinBackoff is the delay before the first retry, it doubles up to MaxBackoff. default: 100ms, 10s
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RetryableErrorCodes default: DefaultRetryableErrorCodes
+	RetryableErrorCodes []int
+	// RetryableStatusCodes default: DefaultRetryableStatusCodes
+	RetryableStatusCodes []int
+}
+
+// WithRetry enables retries. Network errors, RetryableStatusCodes and responses carrying one of
+// RetryableErrorCodes are retried.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *RpcClient) {
+		if policy.MinBackoff <= 0 {
+			policy.MinBackoff = defaultRetryMinBackoff
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			policy.MaxBackoff = defaultRetryMaxBackoff
+		}
+		if policy.RetryableErrorCodes == nil {
+			policy.RetryableErrorCodes = DefaultRetryableErrorCodes
+		}
+		if policy.RetryableStatusCodes == nil {
+			policy.RetryableStatusCodes = DefaultRetryableStatusCodes
+		}
+		c.retry = &policy
+	}
+}
+
+// WithRateLimit limits outgoing requests by a token bucket which refills requestsPerSecond tokens per second
+// and holds at most burst tokens. Retries take tokens as well.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *RpcClient) {
+		c.limiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// IsRetryableErrorCode reports whether the json rpc error code is in DefaultRetryableErrorCodes
+func IsRetryableErrorCode(code int) bool {
+	return containsInt(DefaultRetryableErrorCodes, code)
+}
+
+func (p *RetryPolicy) shouldRetry(ctx context.Context, res httpResult, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return res.transportErr || containsInt(p.RetryableStatusCodes, res.statusCode)
+	}
+	// batch responses are arrays, only a single response is checked
+	var r GeneralResponse
+	if json.Unmarshal(res.body, &r) != nil || r.Error == nil {
+		return false
+	}
+	return containsInt(p.RetryableErrorCodes, r.Error.Code)
+}
+
+func (p *RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	d := p.MinBackoff
+	for i := 0; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	// equal jitter, wait between d/2 and d
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter accepts both delay-seconds and http-date
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func containsInt(list []int, v int) bool {
+	for _, e := range list {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimiter is a token bucket shared by every copy of the RpcClient
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		if l.rate <= 0 {
+			l.mu.Unlock()
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
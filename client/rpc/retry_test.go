@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRetry(t *testing.T) {
+	type response struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name      string
+		responses []response
+		policy    RetryPolicy
+		wantCalls int32
+		wantSlot  uint64
+		wantErr   string
+	}{
+		{
+			name: "rate limited",
+			responses: []response{
+				{statusCode: http.StatusTooManyRequests},
+				{statusCode: http.StatusTooManyRequests},
+				{statusCode: http.StatusOK, body: `{"jsonrpc":"2.0","result":77317717,"id":1}`},
+			},
+			policy:    RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond},
+			wantCalls: 3,
+			wantSlot:  77317717,
+		},
+		{
+			name: "node is behind",
+			responses: []response{
+				{statusCode: http.StatusOK, body: `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 42 slots","data":{"numSlotsBehind":42}},"id":1}`},
+				{statusCode: http.StatusOK, body: `{"jsonrpc":"2.0","result":77317717,"id":1}`},
+			},
+			policy:    RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond},
+			wantCalls: 2,
+			wantSlot:  77317717,
+		},
+		{
+			name: "not retryable",
+			responses: []response{
+				{statusCode: http.StatusOK, body: `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params"},"id":1}`},
+			},
+			policy:    RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond},
+			wantCalls: 1,
+		},
+		{
+			name: "exhausted",
+			responses: []response{
+				{statusCode: http.StatusServiceUnavailable},
+				{statusCode: http.StatusServiceUnavailable},
+				{statusCode: http.StatusServiceUnavailable},
+			},
+			policy:    RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond},
+			wantCalls: 3,
+			wantErr:   "rpc: call error, err: get status code: 503",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				res := tt.responses[n-1]
+				if res.statusCode == http.StatusTooManyRequests {
+					rw.Header().Set("Retry-After", "0")
+				}
+				rw.WriteHeader(res.statusCode)
+				rw.Write([]byte(res.body))
+			}))
+			defer server.Close()
+
+			c := NewRpcClient(server.URL, WithRetry(tt.policy))
+			res, err := c.GetSlot(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.wantSlot, res.Result)
+			}
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-1"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT"))
+	d := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, d > 59*time.Minute && d <= time.Hour)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt := 0; attempt < 10; attempt++ {
+		d := p.backoff(attempt, 0)
+		assert.True(t, d >= 50*time.Millisecond && d <= time.Second, "attempt %v got %v", attempt, d)
+	}
+	assert.Equal(t, 5*time.Second, p.backoff(0, 5*time.Second))
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(50, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, l.wait(context.Background()))
+	}
+	// 2 tokens from burst, 2 more refilled at 50/s
+	assert.True(t, time.Since(start) >= 35*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, l.wait(ctx))
+}
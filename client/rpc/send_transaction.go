@@ -37,3 +37,23 @@ func (c *RpcClient) processSendTransaction(body []byte, rpcErr error) (res SendT
 	err = c.processRpcCall(body, rpcErr, &res)
 	return
 }
+
+// SendTransaction adds `sendTransaction` to the batch
+func (b *Batch) SendTransaction(tx string) *SendTransactionResponse {
+	res := new(SendTransactionResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processSendTransaction(body, rpcErr)
+		return
+	}, "sendTransaction", tx)
+	return res
+}
+
+// SendTransactionWithConfig adds `sendTransaction` to the batch
+func (b *Batch) SendTransactionWithConfig(tx string, cfg SendTransactionConfig) *SendTransactionResponse {
+	res := new(SendTransactionResponse)
+	b.add(func(body []byte, rpcErr error) (err error) {
+		*res, err = b.client.processSendTransaction(body, rpcErr)
+		return
+	}, "sendTransaction", tx, cfg)
+	return res
+}
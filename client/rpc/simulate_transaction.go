@@ -1,9 +1,6 @@
 package rpc
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type SimulateTransactionEncoding string
 
@@ -46,8 +43,10 @@ func (s *RpcClient) SimulateTransaction(ctx context.Context, rawTx string, cfg S
 	if err != nil {
 		return SimulateTransactionResponse{}, err
 	}
-	if res.Error != nil {
-		return SimulateTransactionResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
+
+// TransactionError decodes Err, it returns nil if the simulation succeeded
+func (r SimulateTransactionResponse) TransactionError() (*TransactionError, error) {
+	return ParseTransactionError(r.Err)
+}
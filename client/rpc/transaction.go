@@ -0,0 +1,186 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/36625090/solana-go/types"
+	"github.com/mr-tron/base58"
+)
+
+// TransactionEncoding is the format of transactions returned by `getBlock` and `getTransaction`
+type TransactionEncoding string
+
+const (
+	TransactionEncodingJson       TransactionEncoding = "json"
+	TransactionEncodingJsonParsed TransactionEncoding = "jsonParsed"
+	TransactionEncodingBase58     TransactionEncoding = "base58" // slow
+	TransactionEncodingBase64     TransactionEncoding = "base64"
+)
+
+// TransactionDetails is the level of transaction detail returned by `getBlock`
+type TransactionDetails string
+
+const (
+	TransactionDetailsFull       TransactionDetails = "full"
+	TransactionDetailsSignatures TransactionDetails = "signatures"
+	TransactionDetailsAccounts   TransactionDetails = "accounts"
+	TransactionDetailsNone       TransactionDetails = "none"
+)
+
+// TransactionVersionLegacy is the version of transactions without a version prefix
+const TransactionVersionLegacy TransactionVersion = -1
+
+// TransactionVersion is TransactionVersionLegacy or a version number. It is returned only if
+// maxSupportedTransactionVersion is set in the request.
+type TransactionVersion int
+
+func (v *TransactionVersion) UnmarshalJSON(b []byte) error {
+	if string(b) == `"legacy"` {
+		*v = TransactionVersionLegacy
+		return nil
+	}
+	n, err := strconv.ParseUint(string(b), 10, 8)
+	if err != nil {
+		return fmt.Errorf("unexpected transaction version: %s", b)
+	}
+	*v = TransactionVersion(n)
+	return nil
+}
+
+func (v TransactionVersion) MarshalJSON() ([]byte, error) {
+	if v == TransactionVersionLegacy {
+		return []byte(`"legacy"`), nil
+	}
+	return []byte(strconv.Itoa(int(v))), nil
+}
+
+// TransactionWithMeta is a transaction of `getBlock`
+type TransactionWithMeta struct {
+	Meta        TransactionMeta     `json:"meta"`
+	Transaction EncodedTransaction  `json:"transaction"`
+	Version     *TransactionVersion `json:"version,omitempty"`
+}
+
+// Reward is a reward of a block or a transaction
+type Reward struct {
+	Pubkey      string `json:"pubkey"`
+	Lamports    int64  `json:"lamports"`
+	PostBalance uint64 `json:"postBalance"`
+	RewardType  string `json:"rewardType"` // type of reward: "fee", "rent", "voting", "staking"
+	Commission  *uint8 `json:"commission,omitempty"`
+}
+
+// EncodedTransaction is a transaction in any TransactionEncoding
+//
+//	json: the embedded Transaction
+//	base58, base64: Data and Encoding, use RawTransaction or DecodeTransaction
+//	jsonParsed or the "accounts" transaction details: Parsed
+type EncodedTransaction struct {
+	Transaction
+	Data     string
+	Encoding TransactionEncoding
+	Parsed   *ParsedTransaction
+}
+
+// ParsedTransaction is a transaction in jsonParsed encoding or with the "accounts" transaction details
+type ParsedTransaction struct {
+	Signatures []string `json:"signatures"`
+	// AccountKeys is set with the "accounts" transaction details
+	AccountKeys []ParsedAccountKey `json:"accountKeys,omitempty"`
+	// Message is set with jsonParsed encoding
+	Message *ParsedMessage `json:"message,omitempty"`
+}
+
+type ParsedMessage struct {
+	AccountKeys         []ParsedAccountKey   `json:"accountKeys"`
+	RecentBlockhash     string               `json:"recentBlockhash"`
+	Instructions        []ParsedInstruction  `json:"instructions"`
+	AddressTableLookups []AddressTableLookup `json:"addressTableLookups,omitempty"`
+}
+
+type ParsedAccountKey struct {
+	Pubkey   string `json:"pubkey"`
+	Signer   bool   `json:"signer"`
+	Writable bool   `json:"writable"`
+	Source   string `json:"source,omitempty"` // "transaction" or "lookupTable"
+}
+
+// ParsedInstruction is parsed into Program and Parsed if the node knows the program, otherwise it has Accounts and Data
+type ParsedInstruction struct {
+	ProgramId string      `json:"programId"`
+	Program   string      `json:"program,omitempty"`
+	Parsed    interface{} `json:"parsed,omitempty"`
+	Accounts  []string    `json:"accounts,omitempty"`
+	Data      string      `json:"data,omitempty"`
+}
+
+func (t *EncodedTransaction) UnmarshalJSON(b []byte) error {
+	*t = EncodedTransaction{}
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '[' {
+		var pair []string
+		if err := json.Unmarshal(b, &pair); err != nil || len(pair) != 2 {
+			return fmt.Errorf("unexpected encoded transaction: %s", b)
+		}
+		t.Data = pair[0]
+		t.Encoding = TransactionEncoding(pair[1])
+		return nil
+	}
+
+	var probe struct {
+		AccountKeys json.RawMessage `json:"accountKeys"`
+	}
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return err
+	}
+	// the "accounts" details has the account keys of any encoding at the top level
+	if probe.AccountKeys == nil {
+		if err := json.Unmarshal(b, &t.Transaction); err == nil {
+			t.Encoding = TransactionEncodingJson
+			return nil
+		}
+		t.Transaction = Transaction{}
+	}
+	t.Parsed = new(ParsedTransaction)
+	if err := json.Unmarshal(b, t.Parsed); err != nil {
+		return err
+	}
+	if t.Parsed.Message != nil {
+		t.Encoding = TransactionEncodingJsonParsed
+	}
+	return nil
+}
+
+func (t EncodedTransaction) MarshalJSON() ([]byte, error) {
+	switch {
+	case t.Data != "":
+		return json.Marshal([]string{t.Data, string(t.Encoding)})
+	case t.Parsed != nil:
+		return json.Marshal(t.Parsed)
+	}
+	return json.Marshal(t.Transaction)
+}
+
+// RawTransaction returns the serialized transaction, only transactions in base58 or base64 encoding have it
+func (t EncodedTransaction) RawTransaction() ([]byte, error) {
+	switch t.Encoding {
+	case TransactionEncodingBase64:
+		return base64.StdEncoding.DecodeString(t.Data)
+	case TransactionEncodingBase58:
+		return base58.Decode(t.Data)
+	}
+	return nil, fmt.Errorf("no raw transaction in %v encoding", t.Encoding)
+}
+
+// DecodeTransaction deserializes a transaction in base58 or base64 encoding
+func (t EncodedTransaction) DecodeTransaction() (types.Transaction, error) {
+	raw, err := t.RawTransaction()
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	return types.TransactionDeserialize(raw)
+}
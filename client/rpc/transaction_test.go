@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodedTransaction(t *testing.T) {
+	var tx EncodedTransaction
+	err := json.Unmarshal([]byte(`["Ab1iQxNmY3zqRtEcCiFCp6LeehBE+IEub93/KCjsVOnV6rnr3pvMi6S4myA2l0nrQchMf2/0SLfQFfdysLUVTQgBAAEDztOH5sNvV/6T749Rbp8xjG2J4MUYMd89ewhObW6I5PCGrNHV44k9bHSrzXw2RD1uUB/wdWyJYd4m8kScG0EdjgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA3fS9Owj8B1uBqRaXIGjQg0BL6MlNDbvcZ+i+ZCPSESoBAgIAAQwCAAAAAAEAAAAAAAA=","base64"]`), &tx)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionEncodingBase64, tx.Encoding)
+	decoded, err := tx.DecodeTransaction()
+	assert.Nil(t, err)
+	assert.Equal(t, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5", decoded.Message.RecentBlockHash)
+	assert.Equal(t, []common.PublicKey{
+		common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"),
+		common.SystemProgramID,
+	}, decoded.Message.Accounts)
+
+	tx = EncodedTransaction{}
+	err = json.Unmarshal([]byte(`{"message":{"accountKeys":["EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7","11111111111111111111111111111111"],"header":{"numReadonlySignedAccounts":0,"numReadonlyUnsignedAccounts":1,"numRequiredSignatures":1},"instructions":[{"accounts":[0],"data":"3Bxs4h24hBtQy9rw","programIdIndex":1}],"recentBlockhash":"FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5"},"signatures":["4mmJ6Y2bRCKnxdLSWrsi7D5kg7Gp5N2Xb4KgnCYu7EmSW1QJbByJyCUXz8MNFA2VwBAe2cxErVpXVUbbMy5JPYie"]}`), &tx)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionEncodingJson, tx.Encoding)
+	assert.Nil(t, tx.Parsed)
+	assert.Equal(t, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5", tx.Message.RecentBlockhash)
+	_, err = tx.DecodeTransaction()
+	assert.EqualError(t, err, "no raw transaction in json encoding")
+
+	tx = EncodedTransaction{}
+	err = json.Unmarshal([]byte(`{"message":{"accountKeys":[{"pubkey":"EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7","signer":true,"source":"transaction","writable":true},{"pubkey":"11111111111111111111111111111111","signer":false,"source":"transaction","writable":false}],"instructions":[{"parsed":{"info":{"lamports":1},"type":"transfer"},"program":"system","programId":"11111111111111111111111111111111"}],"recentBlockhash":"FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5"},"signatures":["4mmJ6Y2bRCKnxdLSWrsi7D5kg7Gp5N2Xb4KgnCYu7EmSW1QJbByJyCUXz8MNFA2VwBAe2cxErVpXVUbbMy5JPYie"]}`), &tx)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionEncodingJsonParsed, tx.Encoding)
+	assert.Equal(t, "system", tx.Parsed.Message.Instructions[0].Program)
+	assert.True(t, tx.Parsed.Message.AccountKeys[0].Signer)
+
+	tx = EncodedTransaction{}
+	err = json.Unmarshal([]byte(`{"accountKeys":[{"pubkey":"EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7","signer":true,"source":"transaction","writable":true}],"signatures":["4mmJ6Y2bRCKnxdLSWrsi7D5kg7Gp5N2Xb4KgnCYu7EmSW1QJbByJyCUXz8MNFA2VwBAe2cxErVpXVUbbMy5JPYie"]}`), &tx)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Parsed.Message)
+	assert.Equal(t, "EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", tx.Parsed.AccountKeys[0].Pubkey)
+}
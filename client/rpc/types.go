@@ -51,8 +51,16 @@ type TransactionMeta struct {
 		Index        uint64        `json:"index"`
 		Instructions []Instruction `json:"instructions"`
 	} `json:"innerInstructions"`
-	Err    interface{}            `json:"err"`
-	Status map[string]interface{} `json:"status"`
+	Err             interface{}            `json:"err"`
+	Status          map[string]interface{} `json:"status"`
+	Rewards         []Reward               `json:"rewards,omitempty"`
+	LoadedAddresses *LoadedAddresses       `json:"loadedAddresses,omitempty"`
+}
+
+// LoadedAddresses are the addresses a v0 transaction loaded from address lookup tables
+type LoadedAddresses struct {
+	Writable []string `json:"writable"`
+	Readonly []string `json:"readonly"`
 }
 
 type MessageHeader struct {
@@ -62,10 +70,18 @@ type MessageHeader struct {
 }
 
 type Message struct {
-	Header          MessageHeader `json:"header"`
-	AccountKeys     []string      `json:"accountKeys"`
-	RecentBlockhash string        `json:"recentBlockhash"`
-	Instructions    []Instruction `json:"instructions"`
+	Header              MessageHeader        `json:"header"`
+	AccountKeys         []string             `json:"accountKeys"`
+	RecentBlockhash     string               `json:"recentBlockhash"`
+	Instructions        []Instruction        `json:"instructions"`
+	AddressTableLookups []AddressTableLookup `json:"addressTableLookups,omitempty"`
+}
+
+// AddressTableLookup loads accounts of a v0 transaction from an address lookup table
+type AddressTableLookup struct {
+	AccountKey      string   `json:"accountKey"`
+	WritableIndexes []uint64 `json:"writableIndexes"`
+	ReadonlyIndexes []uint64 `json:"readonlyIndexes"`
 }
 
 type Transaction struct {
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/36625090/solana-go/types"
+)
+
+// ErrBlockhashExpired is returned if the transaction isn't confirmed before its blockhash expires, it can be
+// signed again with a new blockhash safely
+var ErrBlockhashExpired = errors.New("blockhash expired")
+
+const (
+	defaultConfirmRebroadcastInterval = 2 * time.Second
+	defaultConfirmPollInterval        = 500 * time.Millisecond
+)
+
+// TransactionFailedError is returned if the transaction is included in a block but failed
+type TransactionFailedError struct {
+	Signature string
+	Slot      uint64
+	Err       *rpc.TransactionError
+}
+
+func (e *TransactionFailedError) Error() string {
+	return fmt.Sprintf("transaction %v failed at slot %v, %v", e.Signature, e.Slot, e.Err)
+}
+
+func (e *TransactionFailedError) Unwrap() error {
+	return e.Err
+}
+
+// SendAndConfirmConfig is an option config for SendAndConfirmTransaction
+type SendAndConfirmConfig struct {
+	// Commitment to wait for. default: confirmed
+	Commitment rpc.Commitment
+	// RebroadcastInterval is how often the raw transaction is sent again until it is confirmed. default: 2s
+	RebroadcastInterval time.Duration
+	// PollInterval is how often the signature status is checked. default: 500ms
+	PollInterval        time.Duration
+	SkipPreflight       bool
+	PreflightCommitment rpc.Commitment
+}
+
+func (cfg SendAndConfirmConfig) withDefaults() SendAndConfirmConfig {
+	if cfg.Commitment == "" {
+		cfg.Commitment = rpc.CommitmentConfirmed
+	}
+	if cfg.RebroadcastInterval <= 0 {
+		cfg.RebroadcastInterval = defaultConfirmRebroadcastInterval
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultConfirmPollInterval
+	}
+	return cfg
+}
+
+// SendAndConfirmTransaction signs the instructions with the latest blockhash or the one of the blockhash provider, sends the transaction and waits
+// until it reaches the commitment. See SendAndConfirmRawTransaction for the returned errors.
+func (c *Client) SendAndConfirmTransaction(ctx context.Context, param SendTransactionWithSignersParam, cfg SendAndConfirmConfig) (string, error) {
+	latestBlockhash, err := c.blockhash(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get latest blockhash, err: %w", err)
+	}
+	rawTx, err := types.CreateRawTransactionWithSigners(ctx, types.CreateRawTransactionWithSignersParam{
+		Instructions:    param.Instructions,
+		Signers:         param.Signers,
+		FeePayer:        param.FeePayer,
+		RecentBlockHash: latestBlockhash.Blockhash,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build tx, err: %v", err)
+	}
+	return c.SendAndConfirmRawTransaction(ctx, rawTx, latestBlockhash.LastValidBlockHeight, cfg)
+}
+
+// SendAndConfirmRawTransaction sends the signed transaction, rebroadcasts it until it reaches the commitment and
+// returns its signature. lastValidBlockHeight is the one returned with the blockhash of the transaction.
+// It returns an error wrapping ErrBlockhashExpired if the block height passes lastValidBlockHeight first,
+// a *TransactionFailedError if the transaction failed on chain, or the error of the first send, e.g. a preflight failure.
+func (c *Client) SendAndConfirmRawTransaction(ctx context.Context, rawTx []byte, lastValidBlockHeight uint64, cfg SendAndConfirmConfig) (string, error) {
+	cfg = cfg.withDefaults()
+	return c.sendAndConfirm(ctx, rawTx, cfg, func(ctx context.Context, signature string) error {
+		blockHeight, err := c.RpcClient.GetBlockHeight(ctx, rpc.GetBlockHeightConfig{Commitment: cfg.Commitment})
+		if err == nil && blockHeight > lastValidBlockHeight {
+			return fmt.Errorf("%w, signature: %v, last valid block height: %v, block height: %v", ErrBlockhashExpired, signature, lastValidBlockHeight, blockHeight)
+		}
+		return nil
+	})
+}
+
+// sendTransactionConfig is rpc.SendTransactionConfig which always sends maxRetries, a zero stops the node from rebroadcasting
+type sendTransactionConfig struct {
+	SkipPreflight       bool                              `json:"skipPreflight,omitempty"`
+	PreflightCommitment rpc.Commitment                    `json:"preflightCommitment,omitempty"`
+	Encoding            rpc.SendTransactionConfigEncoding `json:"encoding,omitempty"`
+	MaxRetries          uint64                            `json:"maxRetries"`
+}
+
+// sendAndConfirm sends and rebroadcasts the transaction until it reaches the commitment. expired is checked before
+// each rebroadcast, a non nil error means the transaction can't land anymore.
+func (c *Client) sendAndConfirm(ctx context.Context, rawTx []byte, cfg SendAndConfirmConfig, expired func(ctx context.Context, signature string) error) (string, error) {
+	cfg = cfg.withDefaults()
+	encodedTx := base64.StdEncoding.EncodeToString(rawTx)
+	send := func(skipPreflight bool) (string, error) {
+		body, err := c.RpcClient.Call(ctx, "sendTransaction", encodedTx, sendTransactionConfig{
+			SkipPreflight:       skipPreflight,
+			PreflightCommitment: cfg.PreflightCommitment,
+			Encoding:            rpc.SendTransactionConfigEncodingBase64,
+			// rebroadcasting is done here
+			MaxRetries: 0,
+		})
+		if err != nil {
+			return "", fmt.Errorf("rpc: call error, err: %v", err)
+		}
+		var res rpc.SendTransactionResponse
+		if err := json.Unmarshal(body, &res); err != nil {
+			return "", fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+		}
+		err = checkRpcResult(res.GeneralResponse, nil)
+		return res.Result, err
+	}
+
+	signature, err := send(cfg.SkipPreflight)
+	if err != nil {
+		return "", err
+	}
+
+	poll := time.NewTicker(cfg.PollInterval)
+	defer poll.Stop()
+	rebroadcast := time.NewTicker(cfg.RebroadcastInterval)
+	defer rebroadcast.Stop()
+	for {
+		done, err := c.checkSignatureStatus(ctx, signature, cfg.Commitment)
+		if done || err != nil {
+			return signature, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return signature, ctx.Err()
+		case <-poll.C:
+		case <-rebroadcast.C:
+			if expiredErr := expired(ctx, signature); expiredErr != nil {
+				// it may have landed right before the expiry
+				done, err := c.checkSignatureStatus(ctx, signature, cfg.Commitment)
+				if done || err != nil {
+					return signature, err
+				}
+				return signature, expiredErr
+			}
+			// a failed rebroadcast is not fatal, e.g. the node may already have it
+			send(true)
+		}
+	}
+}
+
+// checkSignatureStatus reports the transaction reached the commitment. Node errors are ignored so they are retried by the next poll.
+func (c *Client) checkSignatureStatus(ctx context.Context, signature string, commitment rpc.Commitment) (bool, error) {
+	statuses, err := c.RpcClient.GetSignatureStatuses(ctx, []string{signature})
+	if err != nil || len(statuses) != 1 {
+		return false, nil
+	}
+	status := statuses[0]
+	if status.ConfirmationStatus == nil && status.Confirmations == nil && status.Slot == 0 {
+		// not found
+		return false, nil
+	}
+	if status.Err != nil {
+		txErr, err := rpc.ParseTransactionError(status.Err)
+		if err != nil {
+			return false, fmt.Errorf("transaction %v failed, err: %v", signature, status.Err)
+		}
+		return false, &TransactionFailedError{Signature: signature, Slot: status.Slot, Err: txErr}
+	}
+	return commitmentReached(status, commitment), nil
+}
+
+func commitmentReached(status rpc.GetSignatureStatusesResponse, commitment rpc.Commitment) bool {
+	levels := map[rpc.Commitment]int{
+		rpc.CommitmentProcessed: 0,
+		rpc.CommitmentConfirmed: 1,
+		rpc.CommitmentFinalized: 2,
+	}
+	current := levels[rpc.CommitmentProcessed]
+	if status.ConfirmationStatus != nil {
+		current = levels[*status.ConfirmationStatus]
+	} else if status.Confirmations == nil {
+		// nodes without confirmationStatus mark rooted transactions by null confirmations
+		current = levels[rpc.CommitmentFinalized]
+	}
+	return current >= levels[commitment]
+}
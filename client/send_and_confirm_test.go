@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConfirmNode struct {
+	mu          sync.Mutex
+	calls       map[string]int
+	status      func(polls int) string
+	blockHeight uint64
+	// account returns the data of the system account for getAccountInfo
+	account func(reads int) []byte
+}
+
+func (n *fakeConfirmNode) serve(t *testing.T) *httptest.Server {
+	n.calls = map[string]int{}
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		var r struct {
+			Method string `json:"method"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &r))
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		n.calls[r.Method]++
+		switch r.Method {
+		case "sendTransaction":
+			// the node must not rebroadcast by itself
+			assert.Contains(t, string(body), `"maxRetries":0`)
+			rw.Write([]byte(`{"jsonrpc":"2.0","result":"5rgpegm86vwXotD2Z7WWW1DxpSxmWGQ9g4RMoBJvxJ2xiVF6TNCvGseZ3A1uisew9tGrdKirkkHUGjQW8uNqz9BW","id":1}`))
+		case "getSignatureStatuses":
+			rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":{"context":{"slot":82},"value":[%v]},"id":1}`, n.status(n.calls[r.Method]))))
+		case "getBlockHeight":
+			rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":%d,"id":1}`, n.blockHeight)))
+		case "getAccountInfo":
+			rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":{"context":{"slot":82},"value":{"data":["%v","base64"],"executable":false,"lamports":1447680,"owner":"11111111111111111111111111111111","rentEpoch":0}},"id":1}`, base64.StdEncoding.EncodeToString(n.account(n.calls[r.Method])))))
+		}
+	}))
+}
+
+func (n *fakeConfirmNode) count(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+var fastConfirm = SendAndConfirmConfig{
+	RebroadcastInterval: 20 * time.Millisecond,
+	PollInterval:        5 * time.Millisecond,
+}
+
+func TestSendAndConfirmRawTransaction(t *testing.T) {
+	node := &fakeConfirmNode{
+		blockHeight: 100,
+		status: func(polls int) string {
+			switch {
+			case polls < 3:
+				return "null"
+			case polls < 6:
+				return `{"slot":72,"confirmations":0,"err":null,"confirmationStatus":"processed"}`
+			}
+			return `{"slot":72,"confirmations":1,"err":null,"confirmationStatus":"confirmed"}`
+		},
+	}
+	server := node.serve(t)
+	defer server.Close()
+
+	sig, err := NewClient(server.URL).SendAndConfirmRawTransaction(context.Background(), []byte{1}, 200, fastConfirm)
+	assert.Nil(t, err)
+	assert.Equal(t, "5rgpegm86vwXotD2Z7WWW1DxpSxmWGQ9g4RMoBJvxJ2xiVF6TNCvGseZ3A1uisew9tGrdKirkkHUGjQW8uNqz9BW", sig)
+	assert.Equal(t, 6, node.count("getSignatureStatuses"))
+}
+
+func TestSendAndConfirmRawTransactionExpired(t *testing.T) {
+	node := &fakeConfirmNode{
+		blockHeight: 201,
+		status:      func(int) string { return "null" },
+	}
+	server := node.serve(t)
+	defer server.Close()
+
+	_, err := NewClient(server.URL).SendAndConfirmRawTransaction(context.Background(), []byte{1}, 200, fastConfirm)
+	assert.True(t, errors.Is(err, ErrBlockhashExpired))
+	assert.Equal(t, 1, node.count("getBlockHeight"))
+}
+
+func TestSendAndConfirmRawTransactionFailed(t *testing.T) {
+	node := &fakeConfirmNode{
+		blockHeight: 100,
+		status: func(int) string {
+			return `{"slot":72,"confirmations":1,"err":{"InstructionError":[0,{"Custom":1}]},"confirmationStatus":"confirmed"}`
+		},
+	}
+	server := node.serve(t)
+	defer server.Close()
+
+	_, err := NewClient(server.URL).SendAndConfirmRawTransaction(context.Background(), []byte{1}, 200, fastConfirm)
+	var failed *TransactionFailedError
+	assert.True(t, errors.As(err, &failed))
+	assert.Equal(t, uint64(72), failed.Slot)
+	var txErr *rpc.TransactionError
+	assert.True(t, errors.As(err, &txErr))
+	assert.Equal(t, uint32(1), *txErr.InstructionError.Custom)
+}
+
+func TestSendAndConfirmRawTransactionRebroadcast(t *testing.T) {
+	node := &fakeConfirmNode{
+		blockHeight: 100,
+		status: func(polls int) string {
+			if polls < 20 {
+				return "null"
+			}
+			return `{"slot":72,"confirmations":null,"err":null,"confirmationStatus":"finalized"}`
+		},
+	}
+	server := node.serve(t)
+	defer server.Close()
+
+	cfg := fastConfirm
+	cfg.Commitment = rpc.CommitmentFinalized
+	_, err := NewClient(server.URL).SendAndConfirmRawTransaction(context.Background(), []byte{1}, 200, cfg)
+	assert.Nil(t, err)
+	assert.Greater(t, node.count("sendTransaction"), 1)
+}
@@ -0,0 +1,53 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/36625090/solana-go/client/rpc"
+)
+
+// AccountSubscribeConfig is an option config for `accountSubscribe`
+type AccountSubscribeConfig struct {
+	Commitment rpc.Commitment `json:"commitment,omitempty"`
+	Encoding   rpc.Encoding   `json:"encoding,omitempty"`
+}
+
+// AccountNotification is a notification of `accountSubscribe`
+type AccountNotification struct {
+	Context rpc.Context                   `json:"context"`
+	Value   rpc.GetAccountInfoResultValue `json:"value"`
+}
+
+// AccountSubscription receives notifications when the lamports or data of an account change
+type AccountSubscription struct {
+	*Subscription
+	C <-chan AccountNotification
+}
+
+// AccountSubscribe subscribes an account to receive notifications when the lamports or data change
+func (c *Client) AccountSubscribe(ctx context.Context, base58Addr string) (*AccountSubscription, error) {
+	return c.accountSubscribe(ctx, []interface{}{base58Addr})
+}
+
+// AccountSubscribeWithCfg subscribes an account to receive notifications when the lamports or data change
+func (c *Client) AccountSubscribeWithCfg(ctx context.Context, base58Addr string, cfg AccountSubscribeConfig) (*AccountSubscription, error) {
+	return c.accountSubscribe(ctx, []interface{}{base58Addr, cfg})
+}
+
+func (c *Client) accountSubscribe(ctx context.Context, params []interface{}) (*AccountSubscription, error) {
+	ch := make(chan AccountNotification, c.cfg.BufferSize)
+	sub := newSubscription(c, "accountSubscribe", "accountUnsubscribe", params)
+	sub.deliver = func(result json.RawMessage) (bool, error) {
+		var n AccountNotification
+		if err := json.Unmarshal(result, &n); err != nil {
+			return false, err
+		}
+		sub.push(ch, n)
+		return false, nil
+	}
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &AccountSubscription{Subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,460 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	DevnetWSEndpoint  = "wss://api.devnet.solana.com"
+	TestnetWSEndpoint = "wss://api.testnet.solana.com"
+	MainnetWSEndpoint = "wss://api.mainnet-beta.solana.com"
+)
+
+var (
+	ErrClientClosed   = errors.New("ws: client closed")
+	ErrConnectionLost = errors.New("ws: connection lost")
+	// ErrNotificationDropped is reported on Subscription.Err when a notification channel is full
+	ErrNotificationDropped = errors.New("ws: notification dropped, channel is full")
+)
+
+const (
+	defaultReconnectMinInterval = 500 * time.Millisecond
+	defaultReconnectMaxInterval = 30 * time.Second
+	defaultPingInterval         = 30 * time.Second
+	defaultBufferSize           = 64
+)
+
+// Config is an option config for the websocket client
+type Config struct {
+	// Dialer is used to establish connections. default: websocket.DefaultDialer
+	Dialer *websocket.Dialer
+	// Header is sent with every handshake, e.g. api keys of rpc providers
+	Header http.Header
+	// ReconnectMinInterval is the first delay after a dropped connection, it doubles until ReconnectMaxInterval
+	ReconnectMinInterval time.Duration
+	ReconnectMaxInterval time.Duration
+	// PingInterval keeps idle connections alive, a negative value disables it
+	PingInterval time.Duration
+	// BufferSize is the capacity of every notification channel.
+	// A full channel drops its oldest notification rather than stalling the other subscriptions.
+	BufferSize int
+}
+
+// Client is a pubsub client. It reconnects on dropped connections and resubscribes
+// every active subscription. Notifications sent while disconnected are lost.
+type Client struct {
+	endpoint string
+	cfg      Config
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	nextID  uint64
+	pending map[uint64]*pendingCall
+	subs    map[*Subscription]struct{}
+	active  map[uint64]*Subscription
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+type pendingCall struct {
+	ch  chan callResult
+	sub *Subscription
+}
+
+type callResult struct {
+	result json.RawMessage
+	err    error
+}
+
+type jsonRpcRequest struct {
+	JsonRpc string        `json:"jsonrpc"`
+	Id      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params,omitempty"`
+}
+
+type jsonRpcMessage struct {
+	Id     *uint64            `json:"id"`
+	Result json.RawMessage    `json:"result"`
+	Error  *rpc.ErrorResponse `json:"error"`
+	Method string             `json:"method"`
+	Params *struct {
+		Result       json.RawMessage `json:"result"`
+		Subscription uint64          `json:"subscription"`
+	} `json:"params"`
+}
+
+// Dial connects to a websocket endpoint with default config
+func Dial(ctx context.Context, endpoint string) (*Client, error) {
+	return DialWithConfig(ctx, endpoint, Config{})
+}
+
+// DialWithConfig connects to a websocket endpoint
+func DialWithConfig(ctx context.Context, endpoint string, cfg Config) (*Client, error) {
+	if cfg.Dialer == nil {
+		cfg.Dialer = websocket.DefaultDialer
+	}
+	if cfg.ReconnectMinInterval <= 0 {
+		cfg.ReconnectMinInterval = defaultReconnectMinInterval
+	}
+	if cfg.ReconnectMaxInterval < cfg.ReconnectMinInterval {
+		cfg.ReconnectMaxInterval = defaultReconnectMaxInterval
+	}
+	if cfg.PingInterval == 0 {
+		cfg.PingInterval = defaultPingInterval
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultBufferSize
+	}
+
+	c := &Client{
+		endpoint: endpoint,
+		cfg:      cfg,
+		pending:  map[uint64]*pendingCall{},
+		subs:     map[*Subscription]struct{}{},
+		active:   map[uint64]*Subscription{},
+		closed:   make(chan struct{}),
+	}
+
+	conn, _, err := cfg.Dialer.DialContext(ctx, endpoint, cfg.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial, err: %v", err)
+	}
+	c.conn = conn
+	go c.run(conn)
+	return c, nil
+}
+
+// Close closes the connection and ends every subscription
+func (c *Client) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+
+		c.mu.Lock()
+		conn := c.conn
+		c.conn = nil
+		subs := c.subs
+		c.subs = map[*Subscription]struct{}{}
+		c.active = map[uint64]*Subscription{}
+		c.failPendingLocked(ErrClientClosed)
+		c.mu.Unlock()
+
+		for sub := range subs {
+			sub.finish()
+		}
+		if conn != nil {
+			err = conn.Close()
+		}
+	})
+	return err
+}
+
+func (c *Client) run(conn *websocket.Conn) {
+	for {
+		stopPing := c.keepAlive(conn)
+		c.readLoop(conn)
+		close(stopPing)
+		conn.Close()
+
+		c.mu.Lock()
+		if c.conn == conn {
+			c.conn = nil
+		}
+		c.active = map[uint64]*Subscription{}
+		c.failPendingLocked(ErrConnectionLost)
+		c.mu.Unlock()
+
+		conn = c.reconnect()
+		if conn == nil {
+			return
+		}
+		go c.resubscribe()
+	}
+}
+
+func (c *Client) readLoop(conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg jsonRpcMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		if msg.Id != nil {
+			c.handleResponse(*msg.Id, msg)
+			continue
+		}
+		if msg.Params != nil {
+			c.handleNotification(msg.Params.Subscription, msg.Params.Result)
+		}
+	}
+}
+
+func (c *Client) keepAlive(conn *websocket.Conn) chan struct{} {
+	stop := make(chan struct{})
+	if c.cfg.PingInterval < 0 {
+		return stop
+	}
+	go func() {
+		ticker := time.NewTicker(c.cfg.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.cfg.PingInterval)); err != nil {
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return stop
+}
+
+func (c *Client) reconnect() *websocket.Conn {
+	interval := c.cfg.ReconnectMinInterval
+	for {
+		select {
+		case <-c.closed:
+			return nil
+		case <-time.After(interval):
+		}
+
+		conn, _, err := c.cfg.Dialer.Dial(c.endpoint, c.cfg.Header)
+		if err == nil {
+			c.mu.Lock()
+			select {
+			case <-c.closed:
+				c.mu.Unlock()
+				conn.Close()
+				return nil
+			default:
+			}
+			c.conn = conn
+			c.mu.Unlock()
+			return conn
+		}
+
+		interval *= 2
+		if interval > c.cfg.ReconnectMaxInterval {
+			interval = c.cfg.ReconnectMaxInterval
+		}
+	}
+}
+
+func (c *Client) resubscribe() {
+	c.mu.Lock()
+	subs := make([]*Subscription, 0, len(c.subs))
+	for sub := range c.subs {
+		subs = append(subs, sub)
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		err := c.activate(context.Background(), sub)
+		if err != nil && !errors.Is(err, ErrConnectionLost) && !errors.Is(err, ErrClientClosed) {
+			c.removeSubscription(sub)
+			sub.fail(fmt.Errorf("failed to resubscribe, err: %w", err))
+		}
+	}
+}
+
+func (c *Client) handleResponse(id uint64, msg jsonRpcMessage) {
+	c.mu.Lock()
+	call, ok := c.pending[id]
+	delete(c.pending, id)
+	var serverID uint64
+	orphaned := false
+	if ok && call.sub != nil && msg.Error == nil {
+		// register before the next message is read, notifications may follow right after the response
+		if err := json.Unmarshal(msg.Result, &serverID); err == nil {
+			if _, exist := c.subs[call.sub]; exist {
+				call.sub.serverID = serverID
+				c.active[serverID] = call.sub
+			} else {
+				// the subscription was given up before the node answered
+				orphaned = true
+			}
+		}
+	}
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	if orphaned {
+		// the read loop must go on to receive the response
+		go c.call(context.Background(), call.sub.unsubscribeMethod, []interface{}{serverID}, nil, nil)
+	}
+
+	if msg.Error != nil {
+		call.ch <- callResult{err: msg.Error.RpcError()}
+		return
+	}
+	call.ch <- callResult{result: msg.Result}
+}
+
+func (c *Client) handleNotification(serverID uint64, result json.RawMessage) {
+	c.mu.Lock()
+	sub, ok := c.active[serverID]
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	last, err := sub.deliver(result)
+	if err != nil {
+		sub.fail(fmt.Errorf("failed to decode notification, err: %v", err))
+	}
+	if last {
+		// the node drops one-shot subscriptions by itself
+		c.removeSubscription(sub)
+		sub.finish()
+	}
+}
+
+func (c *Client) failPendingLocked(err error) {
+	for id, call := range c.pending {
+		call.ch <- callResult{err: err}
+		delete(c.pending, id)
+	}
+}
+
+func (c *Client) call(ctx context.Context, method string, params []interface{}, sub *Subscription, result interface{}) error {
+	c.mu.Lock()
+	select {
+	case <-c.closed:
+		c.mu.Unlock()
+		return ErrClientClosed
+	default:
+	}
+	conn := c.conn
+	if conn == nil {
+		c.mu.Unlock()
+		return ErrConnectionLost
+	}
+	c.nextID++
+	id := c.nextID
+	call := &pendingCall{ch: make(chan callResult, 1), sub: sub}
+	c.pending[id] = call
+	c.mu.Unlock()
+
+	j, err := json.Marshal(jsonRpcRequest{
+		JsonRpc: "2.0",
+		Id:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		c.dropPending(id)
+		return fmt.Errorf("failed to prepare payload, err: %v", err)
+	}
+
+	c.writeMu.Lock()
+	err = conn.WriteMessage(websocket.TextMessage, j)
+	c.writeMu.Unlock()
+	if err != nil {
+		c.dropPending(id)
+		return fmt.Errorf("%w, failed to write message, err: %v", ErrConnectionLost, err)
+	}
+
+	select {
+	case res := <-call.ch:
+		if res.err != nil {
+			return res.err
+		}
+		if result == nil {
+			return nil
+		}
+		if err := json.Unmarshal(res.result, result); err != nil {
+			return fmt.Errorf("failed to json decode result, err: %v", err)
+		}
+		return nil
+	case <-ctx.Done():
+		// a subscribe stays pending, the node may still answer it and the subscription has to be dropped then
+		if sub == nil {
+			c.dropPending(id)
+		}
+		return ctx.Err()
+	}
+}
+
+func (c *Client) dropPending(id uint64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+func (c *Client) subscribe(ctx context.Context, sub *Subscription) error {
+	c.mu.Lock()
+	select {
+	case <-c.closed:
+		c.mu.Unlock()
+		return ErrClientClosed
+	default:
+	}
+	c.subs[sub] = struct{}{}
+	c.mu.Unlock()
+
+	err := c.activate(ctx, sub)
+	if err != nil {
+		if errors.Is(err, ErrConnectionLost) {
+			// it will be subscribed once the connection is back
+			return nil
+		}
+		c.removeSubscription(sub)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) activate(ctx context.Context, sub *Subscription) error {
+	return c.call(ctx, sub.method, sub.params, sub, nil)
+}
+
+func (c *Client) removeSubscription(sub *Subscription) (serverID uint64, active bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.subs, sub)
+	if s, ok := c.active[sub.serverID]; ok && s == sub {
+		delete(c.active, sub.serverID)
+		return sub.serverID, true
+	}
+	return 0, false
+}
+
+func (c *Client) unsubscribe(ctx context.Context, sub *Subscription) error {
+	serverID, active := c.removeSubscription(sub)
+	sub.finish()
+	if !active {
+		return nil
+	}
+
+	var ok bool
+	err := c.call(ctx, sub.unsubscribeMethod, []interface{}{serverID}, nil, &ok)
+	if err != nil {
+		if errors.Is(err, ErrConnectionLost) || errors.Is(err, ErrClientClosed) {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("failed to unsubscribe %v", serverID)
+	}
+	return nil
+}
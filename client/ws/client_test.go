@@ -0,0 +1,299 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/36625090/solana-go/client/rpc"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer is a stand-in pubsub node. It answers every `*Subscribe` with an incremental id
+// and every `*Unsubscribe` with true.
+type testServer struct {
+	t        *testing.T
+	server   *httptest.Server
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	nextID   uint64
+	requests chan jsonRpcRequest
+	reject   map[string]bool
+	// hold delays the answers of a method until the channel is closed
+	hold map[string]chan struct{}
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{
+		t:        t,
+		requests: make(chan jsonRpcRequest, 16),
+		reject:   map[string]bool{},
+		hold:     map[string]chan struct{}{},
+	}
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(rw, req, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var r jsonRpcRequest
+			assert.Nil(t, json.Unmarshal(data, &r))
+			s.requests <- r
+
+			s.mu.Lock()
+			hold := s.hold[r.Method]
+			s.mu.Unlock()
+			if hold != nil {
+				<-hold
+			}
+
+			s.mu.Lock()
+			var res string
+			switch {
+			case s.reject[r.Method]:
+				res = fmt.Sprintf(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params"},"id":%d}`, r.Id)
+			case strings.HasSuffix(r.Method, "Unsubscribe"):
+				res = fmt.Sprintf(`{"jsonrpc":"2.0","result":true,"id":%d}`, r.Id)
+			default:
+				s.nextID++
+				res = fmt.Sprintf(`{"jsonrpc":"2.0","result":%d,"id":%d}`, s.nextID, r.Id)
+			}
+			err = conn.WriteMessage(websocket.TextMessage, []byte(res))
+			s.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}))
+	return s
+}
+
+func (s *testServer) url() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http")
+}
+
+func (s *testServer) notify(method string, subscription uint64, result string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg := fmt.Sprintf(`{"jsonrpc":"2.0","method":"%s","params":{"result":%s,"subscription":%d}}`, method, result, subscription)
+	assert.Nil(s.t, s.conn.WriteMessage(websocket.TextMessage, []byte(msg)))
+}
+
+func (s *testServer) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conn.Close()
+}
+
+func (s *testServer) nextRequest() jsonRpcRequest {
+	select {
+	case r := <-s.requests:
+		return r
+	case <-time.After(3 * time.Second):
+		s.t.Fatal("timeout waiting for request")
+	}
+	return jsonRpcRequest{}
+}
+
+func dialTestServer(t *testing.T, s *testServer) *Client {
+	c, err := DialWithConfig(context.Background(), s.url(), Config{
+		ReconnectMinInterval: 10 * time.Millisecond,
+		ReconnectMaxInterval: 50 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+	return c
+}
+
+func TestAccountSubscribe(t *testing.T) {
+	s := newTestServer(t)
+	defer s.server.Close()
+	c := dialTestServer(t, s)
+	defer c.Close()
+
+	sub, err := c.AccountSubscribeWithCfg(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7", AccountSubscribeConfig{
+		Commitment: rpc.CommitmentConfirmed,
+		Encoding:   rpc.EncodingBase64,
+	})
+	assert.Nil(t, err)
+	r := s.nextRequest()
+	assert.Equal(t, "accountSubscribe", r.Method)
+	params, _ := json.Marshal(r.Params)
+	assert.JSONEq(t, `["RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7",{"commitment":"confirmed","encoding":"base64"}]`, string(params))
+
+	s.notify("accountNotification", 1, `{"context":{"slot":5199307},"value":{"data":["","base64"],"executable":false,"lamports":33594,"owner":"11111111111111111111111111111111","rentEpoch":635}}`)
+	select {
+	case n := <-sub.C:
+		assert.Equal(t, AccountNotification{
+			Context: rpc.Context{Slot: 5199307},
+			Value: rpc.GetAccountInfoResultValue{
+				Lamports:  33594,
+				Owner:     "11111111111111111111111111111111",
+				RentEpoch: 635,
+				Data:      []interface{}{"", "base64"},
+			},
+		}, n)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for notification")
+	}
+
+	assert.Nil(t, sub.Unsubscribe(context.Background()))
+	r = s.nextRequest()
+	assert.Equal(t, "accountUnsubscribe", r.Method)
+	assert.Equal(t, []interface{}{float64(1)}, r.Params)
+	<-sub.Done()
+}
+
+func TestSubscribeRejected(t *testing.T) {
+	s := newTestServer(t)
+	defer s.server.Close()
+	s.reject["programSubscribe"] = true
+	c := dialTestServer(t, s)
+	defer c.Close()
+
+	_, err := c.ProgramSubscribe(context.Background(), "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	assert.EqualError(t, err, "rpc response error, code: -32602, message: Invalid params")
+}
+
+func TestSignatureSubscribe(t *testing.T) {
+	s := newTestServer(t)
+	defer s.server.Close()
+	c := dialTestServer(t, s)
+	defer c.Close()
+
+	sub, err := c.SignatureSubscribeWithCfg(context.Background(), "5rgpegm86vwXotD2Z7WWW1DxpSxmWGQ9g4RMoBJvxJ2xiVF6TNCvGseZ3A1uisew9tGrdKirkkHUGjQW8uNqz9BW", SignatureSubscribeConfig{
+		EnableReceivedNotification: true,
+	})
+	assert.Nil(t, err)
+	s.nextRequest()
+
+	s.notify("signatureNotification", 1, `{"context":{"slot":5207624},"value":"receivedSignature"}`)
+	s.notify("signatureNotification", 1, `{"context":{"slot":5207625},"value":{"err":null}}`)
+	assert.Equal(t, SignatureNotification{Context: rpc.Context{Slot: 5207624}, Value: SignatureNotificationValue{Received: true}}, <-sub.C)
+	assert.Equal(t, SignatureNotification{Context: rpc.Context{Slot: 5207625}, Value: SignatureNotificationValue{}}, <-sub.C)
+
+	select {
+	case <-sub.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatal("signature subscription should end after the processed notification")
+	}
+}
+
+func TestResubscribeAfterReconnect(t *testing.T) {
+	s := newTestServer(t)
+	defer s.server.Close()
+	c := dialTestServer(t, s)
+	defer c.Close()
+
+	sub, err := c.SlotSubscribe(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "slotSubscribe", s.nextRequest().Method)
+
+	s.drop()
+	assert.Equal(t, "slotSubscribe", s.nextRequest().Method)
+
+	// the node hands out a new subscription id after reconnecting
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		_, ok := c.active[2]
+		return ok
+	}, 3*time.Second, 10*time.Millisecond)
+	s.notify("slotNotification", 2, `{"parent":75,"root":44,"slot":76}`)
+	assert.Equal(t, SlotNotification{Parent: 75, Root: 44, Slot: 76}, <-sub.C)
+}
+
+func TestLogsFilter(t *testing.T) {
+	tests := []struct {
+		filter LogsFilter
+		want   string
+	}{
+		{filter: LogsFilterAll, want: `"all"`},
+		{filter: LogsFilterAllWithVotes, want: `"allWithVotes"`},
+		{filter: LogsFilterMentions("11111111111111111111111111111111"), want: `{"mentions":["11111111111111111111111111111111"]}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.filter)
+		assert.Nil(t, err)
+		assert.JSONEq(t, tt.want, string(got))
+	}
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	s := newTestServer(t)
+	defer s.server.Close()
+	c, err := DialWithConfig(context.Background(), s.url(), Config{BufferSize: 1})
+	assert.Nil(t, err)
+	defer c.Close()
+
+	slow, err := c.SlotSubscribe(context.Background())
+	assert.Nil(t, err)
+	s.nextRequest()
+	fast, err := c.SlotSubscribe(context.Background())
+	assert.Nil(t, err)
+	s.nextRequest()
+
+	// nobody reads the slow one
+	for i := 1; i <= 3; i++ {
+		s.notify("slotNotification", 1, fmt.Sprintf(`{"parent":%d,"root":%d,"slot":%d}`, i-1, i-1, i))
+	}
+	s.notify("slotNotification", 2, `{"parent":9,"root":9,"slot":10}`)
+	select {
+	case n := <-fast.C:
+		assert.Equal(t, uint64(10), n.Slot)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for notification")
+	}
+
+	select {
+	case err := <-slow.Err():
+		assert.ErrorIs(t, err, ErrNotificationDropped)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for error")
+	}
+	// the oldest ones are dropped
+	n := <-slow.C
+	assert.Equal(t, uint64(3), n.Slot)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	assert.Nil(t, fast.Unsubscribe(ctx))
+}
+
+func TestSubscribeCanceled(t *testing.T) {
+	s := newTestServer(t)
+	defer s.server.Close()
+	c := dialTestServer(t, s)
+	defer c.Close()
+
+	hold := make(chan struct{})
+	s.mu.Lock()
+	s.hold["slotSubscribe"] = hold
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.SlotSubscribe(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "slotSubscribe", s.nextRequest().Method)
+
+	// the late answer is unsubscribed, the node would push notifications for it otherwise
+	close(hold)
+	r := s.nextRequest()
+	assert.Equal(t, "slotUnsubscribe", r.Method)
+	assert.Equal(t, []interface{}{float64(1)}, r.Params)
+}
@@ -0,0 +1,84 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/36625090/solana-go/client/rpc"
+)
+
+// LogsFilter selects which transactions' logs are sent
+type LogsFilter struct {
+	kind     string
+	mentions []string
+}
+
+var (
+	// LogsFilterAll subscribes to all transactions except for simple vote transactions
+	LogsFilterAll = LogsFilter{kind: "all"}
+	// LogsFilterAllWithVotes subscribes to all transactions including simple vote transactions
+	LogsFilterAllWithVotes = LogsFilter{kind: "allWithVotes"}
+)
+
+// LogsFilterMentions subscribes to all transactions that mention the provided pubkey
+func LogsFilterMentions(base58Addr string) LogsFilter {
+	return LogsFilter{mentions: []string{base58Addr}}
+}
+
+func (f LogsFilter) MarshalJSON() ([]byte, error) {
+	if f.mentions != nil {
+		return json.Marshal(map[string][]string{"mentions": f.mentions})
+	}
+	return json.Marshal(f.kind)
+}
+
+// LogsSubscribeConfig is an option config for `logsSubscribe`
+type LogsSubscribeConfig struct {
+	Commitment rpc.Commitment `json:"commitment,omitempty"`
+}
+
+// LogsNotification is a notification of `logsSubscribe`
+type LogsNotification struct {
+	Context rpc.Context           `json:"context"`
+	Value   LogsNotificationValue `json:"value"`
+}
+
+// LogsNotificationValue is a part of LogsNotification
+type LogsNotificationValue struct {
+	Signature string      `json:"signature"`
+	Err       interface{} `json:"err"`
+	Logs      []string    `json:"logs"`
+}
+
+// LogsSubscription receives transaction logs
+type LogsSubscription struct {
+	*Subscription
+	C <-chan LogsNotification
+}
+
+// LogsSubscribe subscribes to transaction logging
+func (c *Client) LogsSubscribe(ctx context.Context, filter LogsFilter) (*LogsSubscription, error) {
+	return c.logsSubscribe(ctx, []interface{}{filter})
+}
+
+// LogsSubscribeWithCfg subscribes to transaction logging
+func (c *Client) LogsSubscribeWithCfg(ctx context.Context, filter LogsFilter, cfg LogsSubscribeConfig) (*LogsSubscription, error) {
+	return c.logsSubscribe(ctx, []interface{}{filter, cfg})
+}
+
+func (c *Client) logsSubscribe(ctx context.Context, params []interface{}) (*LogsSubscription, error) {
+	ch := make(chan LogsNotification, c.cfg.BufferSize)
+	sub := newSubscription(c, "logsSubscribe", "logsUnsubscribe", params)
+	sub.deliver = func(result json.RawMessage) (bool, error) {
+		var n LogsNotification
+		if err := json.Unmarshal(result, &n); err != nil {
+			return false, err
+		}
+		sub.push(ch, n)
+		return false, nil
+	}
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &LogsSubscription{Subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,54 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/36625090/solana-go/client/rpc"
+)
+
+// ProgramSubscribeConfig is an option config for `programSubscribe`
+type ProgramSubscribeConfig struct {
+	Commitment rpc.Commitment                       `json:"commitment,omitempty"`
+	Encoding   rpc.Encoding                         `json:"encoding,omitempty"`
+	Filters    []rpc.GetProgramAccountsConfigFilter `json:"filters,omitempty"`
+}
+
+// ProgramNotification is a notification of `programSubscribe`
+type ProgramNotification struct {
+	Context rpc.Context            `json:"context"`
+	Value   rpc.GetProgramAccounts `json:"value"`
+}
+
+// ProgramSubscription receives notifications when accounts owned by a program change
+type ProgramSubscription struct {
+	*Subscription
+	C <-chan ProgramNotification
+}
+
+// ProgramSubscribe subscribes a program to receive notifications when the lamports or data of its accounts change
+func (c *Client) ProgramSubscribe(ctx context.Context, programId string) (*ProgramSubscription, error) {
+	return c.programSubscribe(ctx, []interface{}{programId})
+}
+
+// ProgramSubscribeWithCfg subscribes a program to receive notifications when the lamports or data of its accounts change
+func (c *Client) ProgramSubscribeWithCfg(ctx context.Context, programId string, cfg ProgramSubscribeConfig) (*ProgramSubscription, error) {
+	return c.programSubscribe(ctx, []interface{}{programId, cfg})
+}
+
+func (c *Client) programSubscribe(ctx context.Context, params []interface{}) (*ProgramSubscription, error) {
+	ch := make(chan ProgramNotification, c.cfg.BufferSize)
+	sub := newSubscription(c, "programSubscribe", "programUnsubscribe", params)
+	sub.deliver = func(result json.RawMessage) (bool, error) {
+		var n ProgramNotification
+		if err := json.Unmarshal(result, &n); err != nil {
+			return false, err
+		}
+		sub.push(ch, n)
+		return false, nil
+	}
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &ProgramSubscription{Subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,30 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// RootSubscription receives the latest root slot anytime a new root is set by the validator
+type RootSubscription struct {
+	*Subscription
+	C <-chan uint64
+}
+
+// RootSubscribe subscribes to receive notification anytime a new root is set by the validator
+func (c *Client) RootSubscribe(ctx context.Context) (*RootSubscription, error) {
+	ch := make(chan uint64, c.cfg.BufferSize)
+	sub := newSubscription(c, "rootSubscribe", "rootUnsubscribe", nil)
+	sub.deliver = func(result json.RawMessage) (bool, error) {
+		var root uint64
+		if err := json.Unmarshal(result, &root); err != nil {
+			return false, err
+		}
+		sub.push(ch, root)
+		return false, nil
+	}
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &RootSubscription{Subscription: sub, C: ch}, nil
+}
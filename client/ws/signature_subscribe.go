@@ -0,0 +1,76 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/36625090/solana-go/client/rpc"
+)
+
+// SignatureSubscribeConfig is an option config for `signatureSubscribe`
+type SignatureSubscribeConfig struct {
+	Commitment                 rpc.Commitment `json:"commitment,omitempty"`
+	EnableReceivedNotification bool           `json:"enableReceivedNotification,omitempty"`
+}
+
+// SignatureNotification is a notification of `signatureSubscribe`
+type SignatureNotification struct {
+	Context rpc.Context                `json:"context"`
+	Value   SignatureNotificationValue `json:"value"`
+}
+
+// SignatureNotificationValue is a part of SignatureNotification.
+// Received is set for the "receivedSignature" notification, otherwise the tx has been processed and Err is its result.
+type SignatureNotificationValue struct {
+	Received bool
+	Err      interface{}
+}
+
+func (v *SignatureNotificationValue) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*v = SignatureNotificationValue{Received: s == "receivedSignature"}
+		return nil
+	}
+	var value struct {
+		Err interface{} `json:"err"`
+	}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	*v = SignatureNotificationValue{Err: value.Err}
+	return nil
+}
+
+// SignatureSubscription receives the status of a transaction. It ends after the processed notification.
+type SignatureSubscription struct {
+	*Subscription
+	C <-chan SignatureNotification
+}
+
+// SignatureSubscribe subscribes to a transaction signature to receive notification when it has reached the commitment
+func (c *Client) SignatureSubscribe(ctx context.Context, signature string) (*SignatureSubscription, error) {
+	return c.signatureSubscribe(ctx, []interface{}{signature})
+}
+
+// SignatureSubscribeWithCfg subscribes to a transaction signature to receive notification when it has reached the commitment
+func (c *Client) SignatureSubscribeWithCfg(ctx context.Context, signature string, cfg SignatureSubscribeConfig) (*SignatureSubscription, error) {
+	return c.signatureSubscribe(ctx, []interface{}{signature, cfg})
+}
+
+func (c *Client) signatureSubscribe(ctx context.Context, params []interface{}) (*SignatureSubscription, error) {
+	ch := make(chan SignatureNotification, c.cfg.BufferSize)
+	sub := newSubscription(c, "signatureSubscribe", "signatureUnsubscribe", params)
+	sub.deliver = func(result json.RawMessage) (bool, error) {
+		var n SignatureNotification
+		if err := json.Unmarshal(result, &n); err != nil {
+			return false, err
+		}
+		sub.push(ch, n)
+		return !n.Value.Received, nil
+	}
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &SignatureSubscription{Subscription: sub, C: ch}, nil
+}
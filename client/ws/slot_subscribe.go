@@ -0,0 +1,37 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SlotNotification is a notification of `slotSubscribe`
+type SlotNotification struct {
+	Parent uint64 `json:"parent"`
+	Root   uint64 `json:"root"`
+	Slot   uint64 `json:"slot"`
+}
+
+// SlotSubscription receives a notification anytime a slot is processed by the validator
+type SlotSubscription struct {
+	*Subscription
+	C <-chan SlotNotification
+}
+
+// SlotSubscribe subscribes to receive notification anytime a slot is processed by the validator
+func (c *Client) SlotSubscribe(ctx context.Context) (*SlotSubscription, error) {
+	ch := make(chan SlotNotification, c.cfg.BufferSize)
+	sub := newSubscription(c, "slotSubscribe", "slotUnsubscribe", nil)
+	sub.deliver = func(result json.RawMessage) (bool, error) {
+		var n SlotNotification
+		if err := json.Unmarshal(result, &n); err != nil {
+			return false, err
+		}
+		sub.push(ch, n)
+		return false, nil
+	}
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &SlotSubscription{Subscription: sub, C: ch}, nil
+}
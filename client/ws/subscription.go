@@ -0,0 +1,78 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// Subscription is the common part of every typed subscription
+type Subscription struct {
+	client            *Client
+	method            string
+	unsubscribeMethod string
+	params            []interface{}
+	serverID          uint64
+
+	// deliver decodes a notification and pushes it to the typed channel.
+	// last reports the node has dropped the subscription after this notification.
+	// It runs on the read loop so it must never block.
+	deliver func(result json.RawMessage) (last bool, err error)
+
+	done     chan struct{}
+	doneOnce sync.Once
+	err      chan error
+}
+
+func newSubscription(c *Client, method, unsubscribeMethod string, params []interface{}) *Subscription {
+	return &Subscription{
+		client:            c,
+		method:            method,
+		unsubscribeMethod: unsubscribeMethod,
+		params:            params,
+		done:              make(chan struct{}),
+		err:               make(chan error, 1),
+	}
+}
+
+// Unsubscribe stops the subscription. The notification channel is not closed, use Done to stop reading it.
+func (s *Subscription) Unsubscribe(ctx context.Context) error {
+	return s.client.unsubscribe(ctx, s)
+}
+
+// Done is closed when the subscription ends, by Unsubscribe, Client.Close or the node itself
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err reports asynchronous failures like a rejected resubscription or an undecodable notification
+func (s *Subscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *Subscription) finish() {
+	s.doneOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *Subscription) fail(err error) {
+	select {
+	case s.err <- err:
+	default:
+	}
+}
+
+// push sends v to the typed notification channel ch without blocking the read loop.
+// A full channel drops its oldest notification and reports ErrNotificationDropped.
+func (s *Subscription) push(ch interface{}, v interface{}) {
+	c, value := reflect.ValueOf(ch), reflect.ValueOf(v)
+	if c.TrySend(value) {
+		return
+	}
+	// the read loop is the only sender, the slot freed here stays free
+	c.TryRecv()
+	c.TrySend(value)
+	s.fail(ErrNotificationDropped)
+}
@@ -22,7 +22,7 @@ var mintPubkey = common.PublicKeyFromString("CW28sov3Dseo2NQSvJJd1yQnMxxmMiPJ2jz
 func main() {
 	c := client.NewClient(rpc.TestnetRPCEndpoint)
 
-	res, err := c.GetRecentBlockhash(context.Background())
+	res, err := c.GetLatestBlockhash(context.Background())
 	if err != nil {
 		log.Fatalf("get recent block hash error, err: %v\n", err)
 	}
@@ -33,7 +33,7 @@ func main() {
 		log.Fatalf("get min balacne for rent exemption, err: %v", err)
 	}
 
-	res, err := c.GetRecentBlockhash(context.Background())
+	res, err := c.GetLatestBlockhash(context.Background())
 	if err != nil {
 		log.Fatalf("get recent block hash error, err: %v\n", err)
 	}
@@ -27,7 +27,7 @@ func main() {
 	log.SetFlags(log.Lshortfile | log.LstdFlags)
 	c := client.NewClient(rpc.TestnetRPCEndpoint)
 
-	res, err := c.GetRecentBlockhash(context.Background())
+	res, err := c.GetLatestBlockhash(context.Background())
 	if err != nil {
 		log.Fatalf("get recent block hash error, err: %v\n", err)
 	}
@@ -118,7 +118,7 @@ func createAccount(token string, ch chan struct{}) {
 
 	c := client.NewClient(rpc.TestnetRPCEndpoint)
 
-	res, err := c.GetRecentBlockhash(context.Background())
+	res, err := c.GetLatestBlockhash(context.Background())
 	if err != nil {
 		log.Fatalf("get recent block hash error, err: %v\n", err)
 	}
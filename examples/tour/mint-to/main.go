@@ -23,7 +23,7 @@ var aliceTokenRandomTokenPubkey = common.PublicKeyFromString("8MZyXdPURRE5Tt5R8k
 func main() {
 	c := client.NewClient(rpc.TestnetRPCEndpoint)
 	//acct, _ := types.AccountFromBase58("9aScuM78feG8JXj3gCUJsXAkaaauUhkMpJyLftkj1XZW")
-	res, err := c.GetRecentBlockhash(context.Background())
+	res, err := c.GetLatestBlockhash(context.Background())
 	if err != nil {
 		log.Fatalf("get recent block hash error, err: %v\n", err)
 	}
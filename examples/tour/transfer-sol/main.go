@@ -19,7 +19,7 @@ func main() {
 	c := client.NewClient(rpc.DevnetRPCEndpoint)
 
 	// recent blockhash reuqest
-	res, err := c.GetRecentBlockhash(context.Background())
+	res, err := c.GetLatestBlockhash(context.Background())
 	if err != nil {
 		log.Fatalf("get recent block hash error, err: %v\n", err)
 	}
@@ -24,7 +24,7 @@ var aliceTokenATAPubkey = common.PublicKeyFromString("81Ck4pb8sZVYacLVHh4KbyiYHX
 func main() {
 	c := client.NewClient(rpc.DevnetRPCEndpoint)
 
-	res, err := c.GetRecentBlockhash(context.Background())
+	res, err := c.GetLatestBlockhash(context.Background())
 	if err != nil {
 		log.Fatalf("get recent block hash error, err: %v\n", err)
 	}
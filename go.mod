@@ -4,7 +4,11 @@ go 1.16
 
 require (
 	filippo.io/edwards25519 v1.0.0-rc.1
+	github.com/gorilla/websocket v1.4.2
+	github.com/klauspost/compress v1.13.6
 	github.com/mr-tron/base58 v1.2.0
 	github.com/near/borsh-go v0.3.1-0.20210831082424-4377deff6791
 	github.com/stretchr/testify v1.7.0
+	github.com/tyler-smith/go-bip39 v1.1.0
+	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
 )
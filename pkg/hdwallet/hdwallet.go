@@ -0,0 +1,133 @@
+// Package hdwallet derives accounts from BIP39 mnemonics along SLIP-0010 ed25519 paths, the same way
+// as Phantom and solana-keygen, e.g. m/44'/501'/0'/0'.
+package hdwallet
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/36625090/solana-go/types"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// HardenedOffset is added to an index to make it hardened, ed25519 only supports hardened derivation
+const HardenedOffset uint32 = 0x80000000
+
+var (
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	ErrInvalidPath     = errors.New("invalid derivation path")
+)
+
+// SolanaPath returns the derivation path of the nth account, m/44'/501'/n'/0'
+func SolanaPath(n uint32) string {
+	return fmt.Sprintf("m/44'/501'/%d'/0'", n)
+}
+
+// NewMnemonic generates a mnemonic of the entropy bits, 128 for 12 words up to 256 for 24 words
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// IsMnemonicValid reports whether the words and the checksum of the mnemonic are valid
+func IsMnemonicValid(mnemonic string) bool {
+	return bip39.IsMnemonicValid(mnemonic)
+}
+
+// NewSeed returns the BIP39 seed of a valid mnemonic, the passphrase may be empty
+func NewSeed(mnemonic, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w, err: %v", ErrInvalidMnemonic, err)
+	}
+	return seed, nil
+}
+
+// Key is an extended private key
+type Key struct {
+	PrivateKey []byte
+	ChainCode  []byte
+}
+
+// NewMasterKey returns the SLIP-0010 ed25519 master key of seed
+func NewMasterKey(seed []byte) Key {
+	return hmacKey([]byte("ed25519 seed"), seed)
+}
+
+// Child returns the hardened child key at index, HardenedOffset is added if it's missing
+func (k Key) Child(index uint32) Key {
+	data := make([]byte, 0, 1+32+4)
+	data = append(data, 0)
+	data = append(data, k.PrivateKey...)
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[33:], index|HardenedOffset)
+	return hmacKey(k.ChainCode, data)
+}
+
+// Derived returns the key at path of the seed, e.g. m/44'/501'/0'/0'
+func Derived(path string, seed []byte) (Key, error) {
+	indexes, err := parsePath(path)
+	if err != nil {
+		return Key{}, err
+	}
+	key := NewMasterKey(seed)
+	for _, index := range indexes {
+		key = key.Child(index)
+	}
+	return key, nil
+}
+
+// DeriveAccount returns the account at path of the seed
+func DeriveAccount(seed []byte, path string) (types.Account, error) {
+	key, err := Derived(path, seed)
+	if err != nil {
+		return types.Account{}, err
+	}
+	return types.AccountFromSeed(key.PrivateKey)
+}
+
+// AccountFromMnemonic returns the nth account of the mnemonic at SolanaPath(n)
+func AccountFromMnemonic(mnemonic, passphrase string, n uint32) (types.Account, error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return types.Account{}, err
+	}
+	return DeriveAccount(seed, SolanaPath(n))
+}
+
+func parsePath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("%w, %v, must start with m", ErrInvalidPath, path)
+	}
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		if !strings.HasSuffix(segment, "'") && !strings.HasSuffix(segment, "h") && !strings.HasSuffix(segment, "H") {
+			return nil, fmt.Errorf("%w, %v, ed25519 only supports hardened indexes", ErrInvalidPath, path)
+		}
+		index, err := strconv.ParseUint(segment[:len(segment)-1], 10, 32)
+		if err != nil || uint32(index) >= HardenedOffset {
+			return nil, fmt.Errorf("%w, %v, invalid index %v", ErrInvalidPath, path, segment)
+		}
+		indexes = append(indexes, uint32(index)|HardenedOffset)
+	}
+	return indexes, nil
+}
+
+func hmacKey(key, data []byte) Key {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	sum := h.Sum(nil)
+	return Key{
+		PrivateKey: sum[:32],
+		ChainCode:  sum[32:],
+	}
+}
@@ -0,0 +1,85 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSeed(t *testing.T) {
+	// BIP39 test vector
+	seed, err := NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "TREZOR")
+	assert.Nil(t, err)
+	assert.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+
+	_, err = NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+	assert.True(t, errors.Is(err, ErrInvalidMnemonic))
+}
+
+func TestNewMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic(256)
+	assert.Nil(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+	assert.True(t, IsMnemonicValid(mnemonic))
+	assert.False(t, IsMnemonicValid(mnemonic+" abandon"))
+}
+
+func TestDerived(t *testing.T) {
+	// SLIP-0010 ed25519 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path       string
+		chainCode  string
+		privateKey string
+	}{
+		{
+			path:       "m",
+			chainCode:  "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+			privateKey: "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		},
+		{
+			path:       "m/0'",
+			chainCode:  "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+			privateKey: "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		},
+		{
+			path:       "m/0H/1H",
+			chainCode:  "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+			privateKey: "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			key, err := Derived(tt.path, seed)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.chainCode, hex.EncodeToString(key.ChainCode))
+			assert.Equal(t, tt.privateKey, hex.EncodeToString(key.PrivateKey))
+		})
+	}
+
+	for _, path := range []string{"", "44'/501'", "m/44'/501'/0", "m/x'", "m/2147483648'"} {
+		_, err := Derived(path, seed)
+		assert.True(t, errors.Is(err, ErrInvalidPath), path)
+	}
+}
+
+func TestAccountFromMnemonic(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	account, err := AccountFromMnemonic(mnemonic, "", 1)
+	assert.Nil(t, err)
+
+	seed, err := NewSeed(mnemonic, "")
+	assert.Nil(t, err)
+	want, err := DeriveAccount(seed, "m/44'/501'/1'/0'")
+	assert.Nil(t, err)
+	assert.Equal(t, want, account)
+
+	// the first account of Phantom
+	other, err := AccountFromMnemonic(mnemonic, "", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk", other.PublicKey.ToBase58())
+	assert.NotEqual(t, account.PublicKey, other.PublicKey)
+}
@@ -0,0 +1,279 @@
+// Package keystore keeps accounts in a passphrase encrypted file. The encryption key is derived from the
+// passphrase by scrypt and every private key is sealed by AES-256-GCM, bound to its label and public key.
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	Version = 1
+
+	kdfScrypt  = "scrypt"
+	saltLength = 32
+	keyLength  = 32
+)
+
+var (
+	ErrKeystoreExists        = errors.New("keystore already exists")
+	ErrUnsupportedVersion    = errors.New("unsupported keystore version")
+	ErrUnsupportedKDF        = errors.New("unsupported key derivation function")
+	ErrWrongPassphrase       = errors.New("wrong passphrase")
+	ErrLabelExists           = errors.New("label already exists")
+	ErrLabelNotFound         = errors.New("label not found")
+	ErrCorruptedAccountEntry = errors.New("corrupted account entry")
+)
+
+// check is sealed with the key so a wrong passphrase is told apart from a corrupted entry
+var check = []byte("solana-go keystore")
+
+// ScryptParams are the cost parameters of the key derivation
+type ScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// DefaultScryptParams takes around 100ms on a laptop
+var DefaultScryptParams = ScryptParams{N: 1 << 15, R: 8, P: 1}
+
+type kdf struct {
+	Name string `json:"name"`
+	ScryptParams
+	Salt []byte `json:"salt"`
+}
+
+type sealed struct {
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type entry struct {
+	Label     string `json:"label"`
+	PublicKey string `json:"publicKey"`
+	sealed
+}
+
+type file struct {
+	Version  int     `json:"version"`
+	KDF      kdf     `json:"kdf"`
+	Check    sealed  `json:"check"`
+	Accounts []entry `json:"accounts"`
+}
+
+// Keystore is a set of labeled accounts stored encrypted at path. Changes are written by Save.
+type Keystore struct {
+	path string
+	aead cipher.AEAD
+	file file
+}
+
+// Create creates an empty keystore at path protected by passphrase, it doesn't overwrite an existing file
+func Create(path, passphrase string) (*Keystore, error) {
+	return CreateWithParams(path, passphrase, DefaultScryptParams)
+}
+
+// CreateWithParams is Create with custom scrypt cost parameters
+func CreateWithParams(path, passphrase string, params ScryptParams) (*Keystore, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%w, %v", ErrKeystoreExists, path)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt, err: %v", err)
+	}
+	ks := &Keystore{
+		path: path,
+		file: file{
+			Version:  Version,
+			KDF:      kdf{Name: kdfScrypt, ScryptParams: params, Salt: salt},
+			Accounts: []entry{},
+		},
+	}
+	if err := ks.deriveKey(passphrase); err != nil {
+		return nil, err
+	}
+	var err error
+	ks.file.Check, err = ks.seal(check, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ks, ks.Save()
+}
+
+// Open reads the keystore at path, it returns ErrWrongPassphrase if passphrase doesn't unlock it
+func Open(path, passphrase string) (*Keystore, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keystore{path: path}
+	if err := json.Unmarshal(data, &ks.file); err != nil {
+		return nil, fmt.Errorf("failed to decode keystore, err: %v", err)
+	}
+	if ks.file.Version != Version {
+		return nil, fmt.Errorf("%w, %v", ErrUnsupportedVersion, ks.file.Version)
+	}
+	if ks.file.KDF.Name != kdfScrypt {
+		return nil, fmt.Errorf("%w, %v", ErrUnsupportedKDF, ks.file.KDF.Name)
+	}
+	if err := ks.deriveKey(passphrase); err != nil {
+		return nil, err
+	}
+	plain, err := ks.open(ks.file.Check, nil)
+	if err != nil || !bytes.Equal(plain, check) {
+		return nil, ErrWrongPassphrase
+	}
+	return ks, nil
+}
+
+// Labels returns the labels of the stored accounts in order
+func (ks *Keystore) Labels() []string {
+	labels := make([]string, 0, len(ks.file.Accounts))
+	for _, e := range ks.file.Accounts {
+		labels = append(labels, e.Label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// PublicKey returns the public key of the account without decrypting it
+func (ks *Keystore) PublicKey(label string) (common.PublicKey, error) {
+	e, ok := ks.find(label)
+	if !ok {
+		return common.PublicKey{}, fmt.Errorf("%w, %v", ErrLabelNotFound, label)
+	}
+	return common.PublicKeyFromString(e.PublicKey), nil
+}
+
+// Add stores account under label
+func (ks *Keystore) Add(label string, account types.Account) error {
+	if _, ok := ks.find(label); ok {
+		return fmt.Errorf("%w, %v", ErrLabelExists, label)
+	}
+	e := entry{Label: label, PublicKey: account.PublicKey.ToBase58()}
+	var err error
+	e.sealed, err = ks.seal(account.PrivateKey, additionalData(e))
+	if err != nil {
+		return err
+	}
+	ks.file.Accounts = append(ks.file.Accounts, e)
+	return nil
+}
+
+// Account decrypts the account stored under label
+func (ks *Keystore) Account(label string) (types.Account, error) {
+	e, ok := ks.find(label)
+	if !ok {
+		return types.Account{}, fmt.Errorf("%w, %v", ErrLabelNotFound, label)
+	}
+	privateKey, err := ks.open(e.sealed, additionalData(e))
+	if err != nil {
+		return types.Account{}, fmt.Errorf("%w, %v", ErrCorruptedAccountEntry, label)
+	}
+	account, err := types.AccountFromBytes(privateKey)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("%w, %v, err: %v", ErrCorruptedAccountEntry, label, err)
+	}
+	if account.PublicKey.ToBase58() != e.PublicKey {
+		return types.Account{}, fmt.Errorf("%w, %v, public key mismatch", ErrCorruptedAccountEntry, label)
+	}
+	return account, nil
+}
+
+// Remove deletes the account stored under label
+func (ks *Keystore) Remove(label string) error {
+	for i, e := range ks.file.Accounts {
+		if e.Label == label {
+			ks.file.Accounts = append(ks.file.Accounts[:i], ks.file.Accounts[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w, %v", ErrLabelNotFound, label)
+}
+
+// Save writes the keystore to its path atomically, readable by the owner only
+func (ks *Keystore) Save() error {
+	data, err := json.MarshalIndent(ks.file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode keystore, err: %v", err)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(ks.path), filepath.Base(ks.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), ks.path)
+}
+
+func (ks *Keystore) find(label string) (entry, bool) {
+	for _, e := range ks.file.Accounts {
+		if e.Label == label {
+			return e, true
+		}
+	}
+	return entry{}, false
+}
+
+func (ks *Keystore) deriveKey(passphrase string) error {
+	params := ks.file.KDF
+	key, err := scrypt.Key([]byte(passphrase), params.Salt, params.N, params.R, params.P, keyLength)
+	if err != nil {
+		return fmt.Errorf("failed to derive key, err: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	ks.aead, err = cipher.NewGCM(block)
+	return err
+}
+
+func (ks *Keystore) seal(plain, additional []byte) (sealed, error) {
+	nonce := make([]byte, ks.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return sealed{}, fmt.Errorf("failed to generate nonce, err: %v", err)
+	}
+	return sealed{
+		Nonce:      nonce,
+		Ciphertext: ks.aead.Seal(nil, nonce, plain, additional),
+	}, nil
+}
+
+func (ks *Keystore) open(s sealed, additional []byte) ([]byte, error) {
+	if len(s.Nonce) != ks.aead.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+	return ks.aead.Open(nil, s.Nonce, s.Ciphertext, additional)
+}
+
+// additionalData binds a sealed private key to its entry, so swapping labels or public keys fails to decrypt
+func additionalData(e entry) []byte {
+	return []byte(e.Label + "\x00" + e.PublicKey)
+}
@@ -0,0 +1,62 @@
+package keystore
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/36625090/solana-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testScryptParams = ScryptParams{N: 1 << 10, R: 8, P: 1}
+
+func TestKeystore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keystore.json")
+
+	ks, err := CreateWithParams(path, "passphrase", testScryptParams)
+	assert.Nil(t, err)
+	alice, bob := types.NewAccount(), types.NewAccount()
+	assert.Nil(t, ks.Add("alice", alice))
+	assert.Nil(t, ks.Add("bob", bob))
+	assert.True(t, errors.Is(ks.Add("alice", bob), ErrLabelExists))
+	assert.Nil(t, ks.Save())
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(data), base64.StdEncoding.EncodeToString(alice.PrivateKey)))
+
+	_, err = CreateWithParams(path, "passphrase", testScryptParams)
+	assert.True(t, errors.Is(err, ErrKeystoreExists))
+	_, err = Open(path, "wrong")
+	assert.Equal(t, ErrWrongPassphrase, err)
+
+	ks, err = Open(path, "passphrase")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, ks.Labels())
+	got, err := ks.Account("alice")
+	assert.Nil(t, err)
+	assert.Equal(t, alice, got)
+	pubkey, err := ks.PublicKey("bob")
+	assert.Nil(t, err)
+	assert.Equal(t, bob.PublicKey, pubkey)
+
+	assert.Nil(t, ks.Remove("alice"))
+	_, err = ks.Account("alice")
+	assert.True(t, errors.Is(err, ErrLabelNotFound))
+
+	// a sealed key moved to another label doesn't decrypt
+	ks.file.Accounts[0].Label = "carol"
+	_, err = ks.Account("carol")
+	assert.True(t, errors.Is(err, ErrCorruptedAccountEntry))
+}
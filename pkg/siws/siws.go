@@ -0,0 +1,258 @@
+// Package siws builds, parses and verifies Sign-In-With-Solana messages, the Solana flavor of EIP-4361
+// used by wallets' signIn method.
+package siws
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/mr-tron/base58"
+)
+
+const (
+	headerSuffix = " wants you to sign in with your Solana account:"
+
+	nonceAlphabet   = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	nonceMinLength  = 8
+	defaultNonceLen = 16
+)
+
+var (
+	ErrInvalidMessage   = errors.New("invalid sign in message")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrDomainMismatch   = errors.New("domain mismatch")
+	ErrNonceMismatch    = errors.New("nonce mismatch")
+	ErrExpired          = errors.New("sign in message expired")
+	ErrNotYetValid      = errors.New("sign in message not yet valid")
+)
+
+// Message is a Sign-In-With-Solana message, only Domain and Address are required
+type Message struct {
+	Domain         string
+	Address        common.PublicKey
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        string
+	Nonce          string
+	IssuedAt       *time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// NewNonce returns a random alphanumeric nonce
+func NewNonce() (string, error) {
+	b := make([]byte, defaultNonceLen)
+	max := big.NewInt(int64(len(nonceAlphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = nonceAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// String returns the text to be signed
+func (m Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address.ToBase58())
+	if m.Statement != "" {
+		b.WriteString("\n\n" + m.Statement)
+	}
+
+	fields := []string{}
+	if m.URI != "" {
+		fields = append(fields, "URI: "+m.URI)
+	}
+	if m.Version != "" {
+		fields = append(fields, "Version: "+m.Version)
+	}
+	if m.ChainID != "" {
+		fields = append(fields, "Chain ID: "+m.ChainID)
+	}
+	if m.Nonce != "" {
+		fields = append(fields, "Nonce: "+m.Nonce)
+	}
+	if m.IssuedAt != nil {
+		fields = append(fields, "Issued At: "+m.IssuedAt.Format(time.RFC3339Nano))
+	}
+	if m.ExpirationTime != nil {
+		fields = append(fields, "Expiration Time: "+m.ExpirationTime.Format(time.RFC3339Nano))
+	}
+	if m.NotBefore != nil {
+		fields = append(fields, "Not Before: "+m.NotBefore.Format(time.RFC3339Nano))
+	}
+	if m.RequestID != "" {
+		fields = append(fields, "Request ID: "+m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		fields = append(fields, "Resources:\n- "+strings.Join(m.Resources, "\n- "))
+	}
+	if len(fields) > 0 {
+		b.WriteString("\n\n" + strings.Join(fields, "\n"))
+	}
+	return b.String()
+}
+
+// Parse parses the text of a Sign-In-With-Solana message
+func Parse(text string) (Message, error) {
+	lines := strings.Split(text, "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], headerSuffix) {
+		return Message{}, fmt.Errorf("%w, no header", ErrInvalidMessage)
+	}
+	m := Message{Domain: strings.TrimSuffix(lines[0], headerSuffix)}
+	if m.Domain == "" {
+		return Message{}, fmt.Errorf("%w, no domain", ErrInvalidMessage)
+	}
+	address, err := base58.Decode(lines[1])
+	if err != nil || len(address) != common.PublicKeyLength {
+		return Message{}, fmt.Errorf("%w, invalid address %v", ErrInvalidMessage, lines[1])
+	}
+	m.Address = common.PublicKeyFromBytes(address)
+
+	lines = lines[2:]
+	if len(lines) == 0 {
+		return m, nil
+	}
+	if len(lines) < 2 || lines[0] != "" {
+		return Message{}, fmt.Errorf("%w, expected an empty line after the address", ErrInvalidMessage)
+	}
+	lines = lines[1:]
+	// the statement is the paragraph before the fields, if any
+	if !isField(lines[0]) {
+		m.Statement = lines[0]
+		lines = lines[1:]
+		if len(lines) == 0 {
+			return m, nil
+		}
+		if len(lines) < 2 || lines[0] != "" {
+			return Message{}, fmt.Errorf("%w, expected an empty line after the statement", ErrInvalidMessage)
+		}
+		lines = lines[1:]
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if line == "Resources:" {
+			for i+1 < len(lines) && strings.HasPrefix(lines[i+1], "- ") {
+				i++
+				m.Resources = append(m.Resources, strings.TrimPrefix(lines[i], "- "))
+			}
+			if len(m.Resources) == 0 {
+				return Message{}, fmt.Errorf("%w, empty resources", ErrInvalidMessage)
+			}
+			continue
+		}
+		key, value, ok := splitField(line)
+		if !ok {
+			return Message{}, fmt.Errorf("%w, unexpected line %q", ErrInvalidMessage, line)
+		}
+		switch key {
+		case "URI":
+			m.URI = value
+		case "Version":
+			m.Version = value
+		case "Chain ID":
+			m.ChainID = value
+		case "Nonce":
+			if len(value) < nonceMinLength || strings.Trim(value, nonceAlphabet) != "" {
+				return Message{}, fmt.Errorf("%w, nonce must be at least %v alphanumeric characters", ErrInvalidMessage, nonceMinLength)
+			}
+			m.Nonce = value
+		case "Issued At":
+			m.IssuedAt, err = parseTime(key, value)
+		case "Expiration Time":
+			m.ExpirationTime, err = parseTime(key, value)
+		case "Not Before":
+			m.NotBefore, err = parseTime(key, value)
+		case "Request ID":
+			m.RequestID = value
+		}
+		if err != nil {
+			return Message{}, err
+		}
+	}
+	return m, nil
+}
+
+// VerifyParam is what the server expects of a sign in message
+type VerifyParam struct {
+	Domain string
+	// Nonce is the one issued to the client, it's not checked if empty
+	Nonce string
+	// Now is the time the message is checked at. default: time.Now()
+	Now time.Time
+}
+
+// Validate checks the domain, the nonce and the validity period of the message
+func (m Message) Validate(param VerifyParam) error {
+	if param.Now.IsZero() {
+		param.Now = time.Now()
+	}
+	if m.Domain != param.Domain {
+		return fmt.Errorf("%w, expected: %v, got: %v", ErrDomainMismatch, param.Domain, m.Domain)
+	}
+	if param.Nonce != "" && m.Nonce != param.Nonce {
+		return ErrNonceMismatch
+	}
+	if m.ExpirationTime != nil && !param.Now.Before(*m.ExpirationTime) {
+		return fmt.Errorf("%w at %v", ErrExpired, m.ExpirationTime.Format(time.RFC3339))
+	}
+	if m.NotBefore != nil && param.Now.Before(*m.NotBefore) {
+		return fmt.Errorf("%w until %v", ErrNotYetValid, m.NotBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Verify parses the signed text, checks the signature is the one of its address and validates it
+func Verify(text string, signature []byte, param VerifyParam) (Message, error) {
+	m, err := Parse(text)
+	if err != nil {
+		return Message{}, err
+	}
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(m.Address.Bytes(), []byte(text), signature) {
+		return Message{}, ErrInvalidSignature
+	}
+	if err := m.Validate(param); err != nil {
+		return Message{}, err
+	}
+	return m, nil
+}
+
+var fieldKeys = []string{"URI", "Version", "Chain ID", "Nonce", "Issued At", "Expiration Time", "Not Before", "Request ID"}
+
+func isField(line string) bool {
+	if line == "Resources:" {
+		return true
+	}
+	_, _, ok := splitField(line)
+	return ok
+}
+
+func splitField(line string) (string, string, bool) {
+	for _, key := range fieldKeys {
+		if strings.HasPrefix(line, key+": ") {
+			return key, strings.TrimPrefix(line, key+": "), true
+		}
+	}
+	return "", "", false
+}
+
+func parseTime(key, value string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid %v %v", ErrInvalidMessage, key, value)
+	}
+	return &t, nil
+}
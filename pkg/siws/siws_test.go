@@ -0,0 +1,126 @@
+package siws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_String(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiration := issuedAt.Add(10 * time.Minute)
+	m := Message{
+		Domain:         "example.com",
+		Address:        common.PublicKeyFromString("2SeBK1pUxnVbY82vN4TEJiWh4GwaGDkffxPegQP3DFPk"),
+		Statement:      "Sign in to Example",
+		URI:            "https://example.com/login",
+		Version:        "1",
+		ChainID:        "mainnet",
+		Nonce:          "32891756ab",
+		IssuedAt:       &issuedAt,
+		ExpirationTime: &expiration,
+		Resources:      []string{"https://example.com/terms", "ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq"},
+	}
+	text := `example.com wants you to sign in with your Solana account:
+2SeBK1pUxnVbY82vN4TEJiWh4GwaGDkffxPegQP3DFPk
+
+Sign in to Example
+
+URI: https://example.com/login
+Version: 1
+Chain ID: mainnet
+Nonce: 32891756ab
+Issued At: 2024-01-02T03:04:05Z
+Expiration Time: 2024-01-02T03:14:05Z
+Resources:
+- https://example.com/terms
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq`
+	assert.Equal(t, text, m.String())
+
+	got, err := Parse(text)
+	assert.Nil(t, err)
+	assert.Equal(t, m, got)
+}
+
+func TestParse(t *testing.T) {
+	address := "2SeBK1pUxnVbY82vN4TEJiWh4GwaGDkffxPegQP3DFPk"
+	tests := []struct {
+		name string
+		text string
+		want Message
+		err  error
+	}{
+		{
+			name: "minimal",
+			text: "example.com wants you to sign in with your Solana account:\n" + address,
+			want: Message{Domain: "example.com", Address: common.PublicKeyFromString(address)},
+		},
+		{
+			name: "no statement",
+			text: "example.com wants you to sign in with your Solana account:\n" + address + "\n\nNonce: abcdefgh",
+			want: Message{Domain: "example.com", Address: common.PublicKeyFromString(address), Nonce: "abcdefgh"},
+		},
+		{
+			name: "no header",
+			text: "example.com wants you to sign in with your Ethereum account:\n" + address,
+			err:  ErrInvalidMessage,
+		},
+		{
+			name: "invalid address",
+			text: "example.com wants you to sign in with your Solana account:\n0x1234",
+			err:  ErrInvalidMessage,
+		},
+		{
+			name: "short nonce",
+			text: "example.com wants you to sign in with your Solana account:\n" + address + "\n\nNonce: abc",
+			err:  ErrInvalidMessage,
+		},
+		{
+			name: "invalid time",
+			text: "example.com wants you to sign in with your Solana account:\n" + address + "\n\nIssued At: yesterday",
+			err:  ErrInvalidMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	account := types.NewAccount()
+	nonce, err := NewNonce()
+	assert.Nil(t, err)
+	now := time.Now()
+	notBefore, expiration := now.Add(-time.Minute), now.Add(time.Minute)
+	text := Message{
+		Domain:         "example.com",
+		Address:        account.PublicKey,
+		Nonce:          nonce,
+		NotBefore:      &notBefore,
+		ExpirationTime: &expiration,
+	}.String()
+	sig := account.Sign([]byte(text))
+
+	m, err := Verify(text, sig, VerifyParam{Domain: "example.com", Nonce: nonce})
+	assert.Nil(t, err)
+	assert.Equal(t, account.PublicKey, m.Address)
+
+	_, err = Verify(text, types.NewAccount().Sign([]byte(text)), VerifyParam{Domain: "example.com", Nonce: nonce})
+	assert.Equal(t, ErrInvalidSignature, err)
+	_, err = Verify(text, sig, VerifyParam{Domain: "evil.com", Nonce: nonce})
+	assert.True(t, errors.Is(err, ErrDomainMismatch))
+	_, err = Verify(text, sig, VerifyParam{Domain: "example.com", Nonce: "otherNonce"})
+	assert.True(t, errors.Is(err, ErrNonceMismatch))
+	_, err = Verify(text, sig, VerifyParam{Domain: "example.com", Now: now.Add(time.Hour)})
+	assert.True(t, errors.Is(err, ErrExpired))
+	_, err = Verify(text, sig, VerifyParam{Domain: "example.com", Now: now.Add(-time.Hour)})
+	assert.True(t, errors.Is(err, ErrNotYetValid))
+}
@@ -0,0 +1,197 @@
+// Package vanity grinds for addresses whose base58 form starts or ends with a pattern.
+package vanity
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	mathrand "math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	defaultProgressInterval = time.Second
+	seedLength              = 16
+)
+
+var (
+	ErrInvalidPattern = errors.New("invalid pattern")
+	ErrEmptyPattern   = errors.New("prefix or suffix is required")
+)
+
+// Config is the pattern and the options of a grind
+type Config struct {
+	Prefix     string
+	Suffix     string
+	IgnoreCase bool
+	// Workers is the number of goroutines grinding. default: runtime.NumCPU()
+	Workers int
+	// OnProgress is called with the stats every ProgressInterval until the grind ends
+	OnProgress func(Stats)
+	// ProgressInterval default: 1s
+	ProgressInterval time.Duration
+}
+
+// Stats of a grind
+type Stats struct {
+	Attempts uint64
+	Elapsed  time.Duration
+}
+
+// Rate returns the attempts per second
+func (s Stats) Rate() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Attempts) / s.Elapsed.Seconds()
+}
+
+// Validate checks the pattern only contains base58 characters, ignoring the case if IgnoreCase is set
+func (cfg Config) Validate() error {
+	if cfg.Prefix == "" && cfg.Suffix == "" {
+		return ErrEmptyPattern
+	}
+	for _, pattern := range []string{cfg.Prefix, cfg.Suffix} {
+		for _, c := range pattern {
+			if strings.ContainsRune(base58Alphabet, c) {
+				continue
+			}
+			if cfg.IgnoreCase && (strings.ContainsRune(base58Alphabet, unicode.ToUpper(c)) || strings.ContainsRune(base58Alphabet, unicode.ToLower(c))) {
+				continue
+			}
+			return fmt.Errorf("%w, %q is not a base58 character", ErrInvalidPattern, c)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the address matches the pattern
+func (cfg Config) Match(address string) bool {
+	if cfg.IgnoreCase {
+		address = strings.ToLower(address)
+		return strings.HasPrefix(address, strings.ToLower(cfg.Prefix)) && strings.HasSuffix(address, strings.ToLower(cfg.Suffix))
+	}
+	return strings.HasPrefix(address, cfg.Prefix) && strings.HasSuffix(address, cfg.Suffix)
+}
+
+// GrindAccount generates accounts until one matches the pattern or ctx is done
+func GrindAccount(ctx context.Context, cfg Config) (types.Account, Stats, error) {
+	result, stats, err := grind(ctx, cfg, func() func() (common.PublicKey, interface{}) {
+		return func() (common.PublicKey, interface{}) {
+			account := types.NewAccount()
+			return account.PublicKey, account
+		}
+	})
+	if err != nil {
+		return types.Account{}, stats, err
+	}
+	return result.(types.Account), stats, nil
+}
+
+// SeedResult is an address found by GrindWithSeed, it is common.CreateWithSeed(base, Seed, programID)
+type SeedResult struct {
+	Seed      string
+	PublicKey common.PublicKey
+}
+
+// GrindWithSeed tries seeds of common.CreateWithSeed until the address matches the pattern or ctx is done
+func GrindWithSeed(ctx context.Context, base, programID common.PublicKey, cfg Config) (SeedResult, Stats, error) {
+	result, stats, err := grind(ctx, cfg, func() func() (common.PublicKey, interface{}) {
+		// seeds aren't secret, a fast generator is enough
+		var s int64
+		binary.Read(rand.Reader, binary.LittleEndian, &s)
+		rng := mathrand.New(mathrand.NewSource(s))
+		seed := make([]byte, seedLength)
+		return func() (common.PublicKey, interface{}) {
+			for i := range seed {
+				seed[i] = base58Alphabet[rng.Intn(len(base58Alphabet))]
+			}
+			pubkey := common.CreateWithSeed(base, string(seed), programID)
+			return pubkey, SeedResult{Seed: string(seed), PublicKey: pubkey}
+		}
+	})
+	if err != nil {
+		return SeedResult{}, stats, err
+	}
+	return result.(SeedResult), stats, nil
+}
+
+// grind runs a generator made by newGenerator in every worker until one generates a matched public key
+func grind(ctx context.Context, cfg Config, newGenerator func() func() (common.PublicKey, interface{})) (interface{}, Stats, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, Stats{}, err
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	if cfg.ProgressInterval <= 0 {
+		cfg.ProgressInterval = defaultProgressInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	var attempts uint64
+	stats := func() Stats {
+		return Stats{Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}
+	}
+
+	found := make(chan interface{}, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generate := newGenerator()
+			for ctx.Err() == nil {
+				pubkey, result := generate()
+				atomic.AddUint64(&attempts, 1)
+				if cfg.Match(pubkey.ToBase58()) {
+					select {
+					case found <- result:
+					default:
+					}
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(cfg.ProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if cfg.OnProgress != nil {
+				cfg.OnProgress(stats())
+			}
+		case <-done:
+			select {
+			case result := <-found:
+				return result, stats(), nil
+			default:
+				return nil, stats(), ctx.Err()
+			}
+		}
+	}
+}
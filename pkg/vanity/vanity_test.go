@@ -0,0 +1,72 @@
+package vanity
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  error
+	}{
+		{cfg: Config{Prefix: "So1"}},
+		{name: "empty", cfg: Config{}, err: ErrEmptyPattern},
+		{name: "zero", cfg: Config{Prefix: "0x"}, err: ErrInvalidPattern},
+		{name: "lowercase l", cfg: Config{Suffix: "sol"}, err: ErrInvalidPattern},
+		{name: "lowercase l ignore case", cfg: Config{Suffix: "sol", IgnoreCase: true}},
+		{name: "uppercase O ignore case", cfg: Config{Prefix: "O", IgnoreCase: true}},
+		{name: "uppercase I ignore case", cfg: Config{Prefix: "I", IgnoreCase: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.cfg.Validate(), tt.err)
+		})
+	}
+}
+
+func TestGrindAccount(t *testing.T) {
+	account, stats, err := GrindAccount(context.Background(), Config{
+		Prefix:     "a",
+		Suffix:     "B",
+		IgnoreCase: true,
+		Workers:    2,
+	})
+	assert.Nil(t, err)
+	address := strings.ToLower(account.PublicKey.ToBase58())
+	assert.True(t, strings.HasPrefix(address, "a") && strings.HasSuffix(address, "b"), address)
+	assert.Greater(t, stats.Attempts, uint64(0))
+	assert.Equal(t, account, types.AccountFromPrivateKeyBytes(account.PrivateKey))
+}
+
+func TestGrindWithSeed(t *testing.T) {
+	base := types.NewAccount().PublicKey
+	result, _, err := GrindWithSeed(context.Background(), base, common.SystemProgramID, Config{Prefix: "A"})
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(result.PublicKey.ToBase58(), "A"))
+	assert.Equal(t, common.CreateWithSeed(base, result.Seed, common.SystemProgramID), result.PublicKey)
+}
+
+func TestGrindCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var progress []Stats
+	// too long to be found
+	_, stats, err := GrindAccount(ctx, Config{
+		Prefix:           "SoLanaGoSoLanaGo",
+		OnProgress:       func(s Stats) { progress = append(progress, s) },
+		ProgressInterval: 10 * time.Millisecond,
+	})
+	assert.NotEmpty(t, progress)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Greater(t, stats.Attempts, uint64(0))
+	assert.Greater(t, stats.Rate(), float64(0))
+}
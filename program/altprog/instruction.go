@@ -0,0 +1,152 @@
+package altprog
+
+import (
+	"encoding/binary"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/pkg/bincode"
+	"github.com/36625090/solana-go/types"
+)
+
+type Instruction uint32
+
+const (
+	InstructionCreateLookupTable Instruction = iota
+	InstructionFreezeLookupTable
+	InstructionExtendLookupTable
+	InstructionDeactivateLookupTable
+	InstructionCloseLookupTable
+)
+
+// DeriveLookupTableAddress returns the address of the lookup table created by the authority at the recent slot
+func DeriveLookupTableAddress(authority common.PublicKey, recentSlot uint64) (common.PublicKey, uint8, error) {
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, recentSlot)
+	address, bump, err := common.FindProgramAddress([][]byte{authority.Bytes(), slot}, common.AddressLookupTableProgramID)
+	return address, uint8(bump), err
+}
+
+// CreateLookupTable creates a lookup table, recentSlot must be a recent finalized slot. It returns the instruction and the table address.
+func CreateLookupTable(authority, payer common.PublicKey, recentSlot uint64) (types.Instruction, common.PublicKey, error) {
+	lookupTable, bump, err := DeriveLookupTableAddress(authority, recentSlot)
+	if err != nil {
+		return types.Instruction{}, common.PublicKey{}, err
+	}
+
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+		RecentSlot  uint64
+		BumpSeed    uint8
+	}{
+		Instruction: InstructionCreateLookupTable,
+		RecentSlot:  recentSlot,
+		BumpSeed:    bump,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+			{PubKey: payer, IsSigner: true, IsWritable: true},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}, lookupTable, nil
+}
+
+// FreezeLookupTable makes the lookup table immutable, it can't be extended or closed anymore
+func FreezeLookupTable(lookupTable, authority common.PublicKey) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionFreezeLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// ExtendLookupTable appends addresses to the lookup table, the payer funds the rent of the extra space.
+// payer can be empty if the table has enough lamports already.
+func ExtendLookupTable(lookupTable, authority, payer common.PublicKey, addresses []common.PublicKey) types.Instruction {
+	data := make([]byte, 0, 12+32*len(addresses))
+	data = append(data, make([]byte, 12)...)
+	binary.LittleEndian.PutUint32(data[:4], uint32(InstructionExtendLookupTable))
+	binary.LittleEndian.PutUint64(data[4:12], uint64(len(addresses)))
+	for _, address := range addresses {
+		data = append(data, address.Bytes()...)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+		{PubKey: authority, IsSigner: true, IsWritable: false},
+	}
+	if payer != (common.PublicKey{}) {
+		accounts = append(accounts,
+			types.AccountMeta{PubKey: payer, IsSigner: true, IsWritable: true},
+			types.AccountMeta{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// DeactivateLookupTable starts the cool down of the lookup table, it can be closed once it is fully deactivated
+func DeactivateLookupTable(lookupTable, authority common.PublicKey) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionDeactivateLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// CloseLookupTable closes a deactivated lookup table and sends its lamports to the recipient
+func CloseLookupTable(lookupTable, authority, recipient common.PublicKey) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionCloseLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+			{PubKey: recipient, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
@@ -0,0 +1,71 @@
+package altprog
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateLookupTable(t *testing.T) {
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	payer := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+
+	instruction, lookupTable, err := CreateLookupTable(authority, payer, 1000)
+	assert.Nil(t, err)
+
+	bump := instruction.Data[12]
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, 1000)
+	expected, err := common.CreateProgramAddress([][]byte{authority.Bytes(), slot, {bump}}, common.AddressLookupTableProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, lookupTable)
+
+	assert.Equal(t, types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+			{PubKey: payer, IsSigner: true, IsWritable: true},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		},
+		Data: []byte{0, 0, 0, 0, 232, 3, 0, 0, 0, 0, 0, 0, bump},
+	}, instruction)
+}
+
+func TestExtendLookupTable(t *testing.T) {
+	lookupTable := common.PublicKeyFromString("9qERNBLXzCqchyfquh2DjUT21xsLym6ynZPRh9TZbEiq")
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	instruction := ExtendLookupTable(lookupTable, authority, authority, []common.PublicKey{common.SystemProgramID, common.TokenProgramID})
+	assert.Equal(t, []types.AccountMeta{
+		{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+		{PubKey: authority, IsSigner: true, IsWritable: false},
+		{PubKey: authority, IsSigner: true, IsWritable: true},
+		{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+	}, instruction.Accounts)
+	expected := []byte{2, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}
+	expected = append(expected, common.SystemProgramID.Bytes()...)
+	expected = append(expected, common.TokenProgramID.Bytes()...)
+	assert.Equal(t, expected, instruction.Data)
+
+	// without a payer
+	instruction = ExtendLookupTable(lookupTable, authority, common.PublicKey{}, nil)
+	assert.Len(t, instruction.Accounts, 2)
+	assert.Equal(t, []byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, instruction.Data)
+}
+
+func TestSimpleInstructions(t *testing.T) {
+	lookupTable := common.PublicKeyFromString("9qERNBLXzCqchyfquh2DjUT21xsLym6ynZPRh9TZbEiq")
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	recipient := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+
+	assert.Equal(t, []byte{1, 0, 0, 0}, FreezeLookupTable(lookupTable, authority).Data)
+	assert.Equal(t, []byte{3, 0, 0, 0}, DeactivateLookupTable(lookupTable, authority).Data)
+
+	instruction := CloseLookupTable(lookupTable, authority, recipient)
+	assert.Equal(t, []byte{4, 0, 0, 0}, instruction.Data)
+	assert.Equal(t, types.AccountMeta{PubKey: recipient, IsSigner: false, IsWritable: true}, instruction.Accounts[2])
+}
@@ -0,0 +1,80 @@
+package altprog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+)
+
+// LookupTableMetaSize is the size of the header, the addresses follow it
+const LookupTableMetaSize = 56
+
+// LookupTableMaxAddresses is the max number of addresses of a lookup table
+const LookupTableMaxAddresses = 256
+
+// DeactivationSlotNone is the deactivation slot of an active lookup table
+const DeactivationSlotNone uint64 = math.MaxUint64
+
+type ProgramState uint32
+
+const (
+	ProgramStateUninitialized ProgramState = iota
+	ProgramStateLookupTable
+)
+
+// LookupTableAccount is an address lookup table account
+type LookupTableAccount struct {
+	DeactivationSlot           uint64
+	LastExtendedSlot           uint64
+	LastExtendedSlotStartIndex uint8
+	// Authority is nil if the table is frozen
+	Authority *common.PublicKey
+	Addresses []common.PublicKey
+}
+
+// IsActive reports the lookup table isn't deactivated
+func (a LookupTableAccount) IsActive() bool {
+	return a.DeactivationSlot == DeactivationSlotNone
+}
+
+// AddressLookupTableAccount converts it for types.NewMessageV0
+func (a LookupTableAccount) AddressLookupTableAccount(key common.PublicKey) types.AddressLookupTableAccount {
+	return types.AddressLookupTableAccount{
+		Key:       key,
+		Addresses: a.Addresses,
+	}
+}
+
+func LookupTableAccountDeserialize(data []byte) (LookupTableAccount, error) {
+	if len(data) < LookupTableMetaSize {
+		return LookupTableAccount{}, fmt.Errorf("lookup table data size is not enough")
+	}
+	if state := ProgramState(binary.LittleEndian.Uint32(data[:4])); state != ProgramStateLookupTable {
+		return LookupTableAccount{}, fmt.Errorf("unexpected program state: %v", state)
+	}
+	if (len(data)-LookupTableMetaSize)%32 != 0 {
+		return LookupTableAccount{}, fmt.Errorf("lookup table addresses data size is invalid")
+	}
+
+	var authority *common.PublicKey
+	if data[21] == 1 {
+		key := common.PublicKeyFromBytes(data[22:54])
+		authority = &key
+	}
+
+	addresses := make([]common.PublicKey, 0, (len(data)-LookupTableMetaSize)/32)
+	for i := LookupTableMetaSize; i < len(data); i += 32 {
+		addresses = append(addresses, common.PublicKeyFromBytes(data[i:i+32]))
+	}
+
+	return LookupTableAccount{
+		DeactivationSlot:           binary.LittleEndian.Uint64(data[4:12]),
+		LastExtendedSlot:           binary.LittleEndian.Uint64(data[12:20]),
+		LastExtendedSlotStartIndex: data[20],
+		Authority:                  authority,
+		Addresses:                  addresses,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package altprog
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupTableAccountDeserialize(t *testing.T) {
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	data := make([]byte, LookupTableMetaSize)
+	binary.LittleEndian.PutUint32(data[:4], uint32(ProgramStateLookupTable))
+	binary.LittleEndian.PutUint64(data[4:12], DeactivationSlotNone)
+	binary.LittleEndian.PutUint64(data[12:20], 100)
+	data[20] = 1
+	data[21] = 1
+	copy(data[22:54], authority.Bytes())
+	data = append(data, common.SystemProgramID.Bytes()...)
+	data = append(data, common.TokenProgramID.Bytes()...)
+
+	account, err := LookupTableAccountDeserialize(data)
+	assert.Nil(t, err)
+	assert.Equal(t, LookupTableAccount{
+		DeactivationSlot:           DeactivationSlotNone,
+		LastExtendedSlot:           100,
+		LastExtendedSlotStartIndex: 1,
+		Authority:                  &authority,
+		Addresses:                  []common.PublicKey{common.SystemProgramID, common.TokenProgramID},
+	}, account)
+	assert.True(t, account.IsActive())
+
+	// frozen
+	data[21] = 0
+	account, err = LookupTableAccountDeserialize(data)
+	assert.Nil(t, err)
+	assert.Nil(t, account.Authority)
+
+	_, err = LookupTableAccountDeserialize(data[:LookupTableMetaSize+1])
+	assert.EqualError(t, err, "lookup table addresses data size is invalid")
+	_, err = LookupTableAccountDeserialize(make([]byte, LookupTableMetaSize))
+	assert.EqualError(t, err, "unexpected program state: 0")
+}
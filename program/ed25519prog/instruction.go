@@ -0,0 +1,182 @@
+package ed25519prog
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+)
+
+const (
+	PublicKeySize = 32
+	SignatureSize = 64
+	// SignatureOffsetsStart is where the offsets start, after the signature count and a padding byte
+	SignatureOffsetsStart = 2
+	SignatureOffsetsSize  = 14
+
+	// CurrentInstruction as an instruction index refers to the verify instruction itself
+	CurrentInstruction uint16 = 0xffff
+)
+
+var ErrInvalidInstructionData = errors.New("invalid ed25519 instruction data")
+
+// SignatureOffsets locates a signature, its public key and its message in the instructions of the transaction
+type SignatureOffsets struct {
+	SignatureOffset           uint16
+	SignatureInstructionIndex uint16
+	PublicKeyOffset           uint16
+	PublicKeyInstructionIndex uint16
+	MessageDataOffset         uint16
+	MessageDataSize           uint16
+	MessageInstructionIndex   uint16
+}
+
+// Verification is a signature checked by the ed25519 program
+type Verification struct {
+	PublicKey common.PublicKey
+	Signature []byte
+	Message   []byte
+}
+
+// NewVerifyInstruction packs the verifications into one instruction, the program fails the transaction if one
+// of the signatures is invalid
+func NewVerifyInstruction(verifications []Verification) (types.Instruction, error) {
+	if len(verifications) == 0 || len(verifications) > 255 {
+		return types.Instruction{}, fmt.Errorf("number of verifications must be in 1~255, got: %v", len(verifications))
+	}
+
+	dataStart := SignatureOffsetsStart + len(verifications)*SignatureOffsetsSize
+	size := dataStart
+	for _, v := range verifications {
+		if len(v.Signature) != SignatureSize {
+			return types.Instruction{}, fmt.Errorf("invalid signature length, expected: %v, got: %v", SignatureSize, len(v.Signature))
+		}
+		size += PublicKeySize + SignatureSize + len(v.Message)
+	}
+	if size > 0xffff {
+		return types.Instruction{}, fmt.Errorf("instruction data too large, size: %v", size)
+	}
+
+	data := make([]byte, dataStart, size)
+	data[0] = uint8(len(verifications))
+	for i, v := range verifications {
+		offsets := SignatureOffsets{
+			PublicKeyOffset:           uint16(len(data)),
+			PublicKeyInstructionIndex: CurrentInstruction,
+			SignatureOffset:           uint16(len(data) + PublicKeySize),
+			SignatureInstructionIndex: CurrentInstruction,
+			MessageDataOffset:         uint16(len(data) + PublicKeySize + SignatureSize),
+			MessageDataSize:           uint16(len(v.Message)),
+			MessageInstructionIndex:   CurrentInstruction,
+		}
+		offsets.put(data[SignatureOffsetsStart+i*SignatureOffsetsSize:])
+		data = append(data, v.PublicKey[:]...)
+		data = append(data, v.Signature...)
+		data = append(data, v.Message...)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Ed25519ProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}, nil
+}
+
+// NewVerifyInstructionWithAccount signs message by account and returns the instruction verifying it
+func NewVerifyInstructionWithAccount(account types.Account, message []byte) (types.Instruction, error) {
+	return NewVerifyInstruction([]Verification{
+		{
+			PublicKey: account.PublicKey,
+			Signature: ed25519.Sign(account.PrivateKey, message),
+			Message:   message,
+		},
+	})
+}
+
+// ParseVerifyInstruction decodes the verifications of the ed25519 instruction at index of the message,
+// the data referred in other instructions is resolved
+func ParseVerifyInstruction(message types.Message, index int) ([]Verification, error) {
+	if index < 0 || index >= len(message.Instructions) {
+		return nil, fmt.Errorf("instruction index %v out of range", index)
+	}
+	instruction := message.Instructions[index]
+	if instruction.ProgramIDIndex >= len(message.Accounts) || message.Accounts[instruction.ProgramIDIndex] != common.Ed25519ProgramID {
+		return nil, fmt.Errorf("instruction %v is not an ed25519 instruction", index)
+	}
+
+	data := instruction.Data
+	if len(data) < SignatureOffsetsStart {
+		return nil, fmt.Errorf("%w, no signature count", ErrInvalidInstructionData)
+	}
+	count := int(data[0])
+	if len(data) < SignatureOffsetsStart+count*SignatureOffsetsSize {
+		return nil, fmt.Errorf("%w, not enough offsets", ErrInvalidInstructionData)
+	}
+
+	get := func(instructionIndex, offset uint16, size int) ([]byte, error) {
+		source := data
+		if instructionIndex != CurrentInstruction {
+			if int(instructionIndex) >= len(message.Instructions) {
+				return nil, fmt.Errorf("%w, instruction index %v out of range", ErrInvalidInstructionData, instructionIndex)
+			}
+			source = message.Instructions[instructionIndex].Data
+		}
+		if int(offset)+size > len(source) {
+			return nil, fmt.Errorf("%w, offset %v size %v out of range", ErrInvalidInstructionData, offset, size)
+		}
+		return source[offset : int(offset)+size], nil
+	}
+
+	verifications := make([]Verification, 0, count)
+	for i := 0; i < count; i++ {
+		offsets := parseSignatureOffsets(data[SignatureOffsetsStart+i*SignatureOffsetsSize:])
+		pubkey, err := get(offsets.PublicKeyInstructionIndex, offsets.PublicKeyOffset, PublicKeySize)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := get(offsets.SignatureInstructionIndex, offsets.SignatureOffset, SignatureSize)
+		if err != nil {
+			return nil, err
+		}
+		msg, err := get(offsets.MessageInstructionIndex, offsets.MessageDataOffset, int(offsets.MessageDataSize))
+		if err != nil {
+			return nil, err
+		}
+		verifications = append(verifications, Verification{
+			PublicKey: common.PublicKeyFromBytes(pubkey),
+			Signature: signature,
+			Message:   msg,
+		})
+	}
+	return verifications, nil
+}
+
+// Verify reports whether the signature is valid, as the program checks it
+func (v Verification) Verify() bool {
+	return len(v.Signature) == SignatureSize && ed25519.Verify(v.PublicKey.Bytes(), v.Message, v.Signature)
+}
+
+func (o SignatureOffsets) put(b []byte) {
+	binary.LittleEndian.PutUint16(b[0:], o.SignatureOffset)
+	binary.LittleEndian.PutUint16(b[2:], o.SignatureInstructionIndex)
+	binary.LittleEndian.PutUint16(b[4:], o.PublicKeyOffset)
+	binary.LittleEndian.PutUint16(b[6:], o.PublicKeyInstructionIndex)
+	binary.LittleEndian.PutUint16(b[8:], o.MessageDataOffset)
+	binary.LittleEndian.PutUint16(b[10:], o.MessageDataSize)
+	binary.LittleEndian.PutUint16(b[12:], o.MessageInstructionIndex)
+}
+
+func parseSignatureOffsets(b []byte) SignatureOffsets {
+	return SignatureOffsets{
+		SignatureOffset:           binary.LittleEndian.Uint16(b[0:]),
+		SignatureInstructionIndex: binary.LittleEndian.Uint16(b[2:]),
+		PublicKeyOffset:           binary.LittleEndian.Uint16(b[4:]),
+		PublicKeyInstructionIndex: binary.LittleEndian.Uint16(b[6:]),
+		MessageDataOffset:         binary.LittleEndian.Uint16(b[8:]),
+		MessageDataSize:           binary.LittleEndian.Uint16(b[10:]),
+		MessageInstructionIndex:   binary.LittleEndian.Uint16(b[12:]),
+	}
+}
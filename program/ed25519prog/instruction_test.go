@@ -0,0 +1,81 @@
+package ed25519prog
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVerifyInstruction(t *testing.T) {
+	account := types.NewAccount()
+	instruction, err := NewVerifyInstructionWithAccount(account, []byte("hello"))
+	assert.Nil(t, err)
+	assert.Equal(t, common.Ed25519ProgramID, instruction.ProgramID)
+
+	// the layout of a single signature is the one of @solana/web3.js
+	assert.Equal(t, []byte{1, 0}, instruction.Data[:2])
+	assert.Equal(t, SignatureOffsets{
+		SignatureOffset:           48,
+		SignatureInstructionIndex: CurrentInstruction,
+		PublicKeyOffset:           16,
+		PublicKeyInstructionIndex: CurrentInstruction,
+		MessageDataOffset:         112,
+		MessageDataSize:           5,
+		MessageInstructionIndex:   CurrentInstruction,
+	}, parseSignatureOffsets(instruction.Data[2:]))
+	assert.Equal(t, account.PublicKey.Bytes(), instruction.Data[16:48])
+	assert.Equal(t, account.Sign([]byte("hello")), instruction.Data[48:112])
+	assert.Equal(t, []byte("hello"), instruction.Data[112:])
+
+	_, err = NewVerifyInstruction(nil)
+	assert.NotNil(t, err)
+	_, err = NewVerifyInstruction([]Verification{{PublicKey: account.PublicKey, Signature: []byte{1}}})
+	assert.NotNil(t, err)
+}
+
+func TestParseVerifyInstruction(t *testing.T) {
+	alice, bob := types.NewAccount(), types.NewAccount()
+	verifications := []Verification{
+		{PublicKey: alice.PublicKey, Signature: alice.Sign([]byte("alice")), Message: []byte("alice")},
+		{PublicKey: bob.PublicKey, Signature: bob.Sign([]byte("bob")), Message: []byte("bob")},
+	}
+	instruction, err := NewVerifyInstruction(verifications)
+	assert.Nil(t, err)
+
+	// the message of the second instruction lives in the data of the first one
+	referring := make([]byte, SignatureOffsetsStart+SignatureOffsetsSize)
+	referring[0] = 1
+	SignatureOffsets{
+		SignatureOffset:           binary.LittleEndian.Uint16(instruction.Data[2:]),
+		SignatureInstructionIndex: 0,
+		PublicKeyOffset:           binary.LittleEndian.Uint16(instruction.Data[6:]),
+		PublicKeyInstructionIndex: 0,
+		MessageDataOffset:         binary.LittleEndian.Uint16(instruction.Data[10:]),
+		MessageDataSize:           5,
+		MessageInstructionIndex:   0,
+	}.put(referring[SignatureOffsetsStart:])
+
+	feePayer := types.NewAccount().PublicKey
+	message := types.NewMessage(feePayer, []types.Instruction{
+		instruction,
+		{ProgramID: common.Ed25519ProgramID, Accounts: []types.AccountMeta{}, Data: referring},
+	}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+
+	got, err := ParseVerifyInstruction(message, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, verifications, got)
+	for _, v := range got {
+		assert.True(t, v.Verify())
+	}
+
+	got, err = ParseVerifyInstruction(message, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, verifications[:1], got)
+
+	message.Instructions[1].Data = referring[:5]
+	_, err = ParseVerifyInstruction(message, 1)
+	assert.ErrorIs(t, err, ErrInvalidInstructionData)
+}
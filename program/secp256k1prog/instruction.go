@@ -0,0 +1,184 @@
+package secp256k1prog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	EthAddressSize = 20
+	SignatureSize  = 64
+	// SignatureOffsetsStart is where the offsets start, after the signature count
+	SignatureOffsetsStart = 1
+	SignatureOffsetsSize  = 11
+)
+
+var ErrInvalidInstructionData = errors.New("invalid secp256k1 instruction data")
+
+type EthAddress [EthAddressSize]byte
+
+// EthAddressFromPublicKey returns the eth address of an uncompressed secp256k1 public key,
+// 65 bytes with the 0x04 prefix or 64 bytes without it
+func EthAddressFromPublicKey(pubkey []byte) (EthAddress, error) {
+	if len(pubkey) == 65 && pubkey[0] == 0x04 {
+		pubkey = pubkey[1:]
+	}
+	if len(pubkey) != 64 {
+		return EthAddress{}, fmt.Errorf("invalid uncompressed public key length: %v", len(pubkey))
+	}
+	h := sha3.NewLegacyKeccak256()
+	h.Write(pubkey)
+	var address EthAddress
+	copy(address[:], h.Sum(nil)[12:])
+	return address, nil
+}
+
+// SignatureOffsets locates a signature, its eth address and its message in the instructions of the transaction
+type SignatureOffsets struct {
+	SignatureOffset            uint16
+	SignatureInstructionIndex  uint8
+	EthAddressOffset           uint16
+	EthAddressInstructionIndex uint8
+	MessageDataOffset          uint16
+	MessageDataSize            uint16
+	MessageInstructionIndex    uint8
+}
+
+// Verification is a signature checked by the secp256k1 program. The signature is over keccak256(message)
+// and the program recovers the signer's eth address from it by the recovery id.
+type Verification struct {
+	EthAddress EthAddress
+	Signature  []byte
+	RecoveryID uint8
+	Message    []byte
+}
+
+// NewVerifyInstruction packs the verifications into one instruction. instructionIndex is the index of the
+// instruction itself in the transaction, the offsets refer to it.
+func NewVerifyInstruction(verifications []Verification, instructionIndex uint8) (types.Instruction, error) {
+	if len(verifications) == 0 || len(verifications) > 255 {
+		return types.Instruction{}, fmt.Errorf("number of verifications must be in 1~255, got: %v", len(verifications))
+	}
+
+	dataStart := SignatureOffsetsStart + len(verifications)*SignatureOffsetsSize
+	size := dataStart
+	for _, v := range verifications {
+		if len(v.Signature) != SignatureSize {
+			return types.Instruction{}, fmt.Errorf("invalid signature length, expected: %v, got: %v", SignatureSize, len(v.Signature))
+		}
+		size += EthAddressSize + SignatureSize + 1 + len(v.Message)
+	}
+	if size > 0xffff {
+		return types.Instruction{}, fmt.Errorf("instruction data too large, size: %v", size)
+	}
+
+	data := make([]byte, dataStart, size)
+	data[0] = uint8(len(verifications))
+	for i, v := range verifications {
+		offsets := SignatureOffsets{
+			EthAddressOffset:           uint16(len(data)),
+			EthAddressInstructionIndex: instructionIndex,
+			SignatureOffset:            uint16(len(data) + EthAddressSize),
+			SignatureInstructionIndex:  instructionIndex,
+			MessageDataOffset:          uint16(len(data) + EthAddressSize + SignatureSize + 1),
+			MessageDataSize:            uint16(len(v.Message)),
+			MessageInstructionIndex:    instructionIndex,
+		}
+		offsets.put(data[SignatureOffsetsStart+i*SignatureOffsetsSize:])
+		data = append(data, v.EthAddress[:]...)
+		data = append(data, v.Signature...)
+		data = append(data, v.RecoveryID)
+		data = append(data, v.Message...)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Secp256k1ProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}, nil
+}
+
+// ParseVerifyInstruction decodes the verifications of the secp256k1 instruction at index of the message,
+// the data referred in other instructions is resolved
+func ParseVerifyInstruction(message types.Message, index int) ([]Verification, error) {
+	if index < 0 || index >= len(message.Instructions) {
+		return nil, fmt.Errorf("instruction index %v out of range", index)
+	}
+	instruction := message.Instructions[index]
+	if instruction.ProgramIDIndex >= len(message.Accounts) || message.Accounts[instruction.ProgramIDIndex] != common.Secp256k1ProgramID {
+		return nil, fmt.Errorf("instruction %v is not a secp256k1 instruction", index)
+	}
+
+	data := instruction.Data
+	if len(data) < SignatureOffsetsStart {
+		return nil, fmt.Errorf("%w, no signature count", ErrInvalidInstructionData)
+	}
+	count := int(data[0])
+	if len(data) < SignatureOffsetsStart+count*SignatureOffsetsSize {
+		return nil, fmt.Errorf("%w, not enough offsets", ErrInvalidInstructionData)
+	}
+
+	get := func(instructionIndex uint8, offset uint16, size int) ([]byte, error) {
+		if int(instructionIndex) >= len(message.Instructions) {
+			return nil, fmt.Errorf("%w, instruction index %v out of range", ErrInvalidInstructionData, instructionIndex)
+		}
+		source := message.Instructions[instructionIndex].Data
+		if int(offset)+size > len(source) {
+			return nil, fmt.Errorf("%w, offset %v size %v out of range", ErrInvalidInstructionData, offset, size)
+		}
+		return source[offset : int(offset)+size], nil
+	}
+
+	verifications := make([]Verification, 0, count)
+	for i := 0; i < count; i++ {
+		offsets := parseSignatureOffsets(data[SignatureOffsetsStart+i*SignatureOffsetsSize:])
+		ethAddress, err := get(offsets.EthAddressInstructionIndex, offsets.EthAddressOffset, EthAddressSize)
+		if err != nil {
+			return nil, err
+		}
+		// the recovery id follows the signature
+		signature, err := get(offsets.SignatureInstructionIndex, offsets.SignatureOffset, SignatureSize+1)
+		if err != nil {
+			return nil, err
+		}
+		msg, err := get(offsets.MessageInstructionIndex, offsets.MessageDataOffset, int(offsets.MessageDataSize))
+		if err != nil {
+			return nil, err
+		}
+		v := Verification{
+			Signature:  signature[:SignatureSize],
+			RecoveryID: signature[SignatureSize],
+			Message:    msg,
+		}
+		copy(v.EthAddress[:], ethAddress)
+		verifications = append(verifications, v)
+	}
+	return verifications, nil
+}
+
+func (o SignatureOffsets) put(b []byte) {
+	binary.LittleEndian.PutUint16(b[0:], o.SignatureOffset)
+	b[2] = o.SignatureInstructionIndex
+	binary.LittleEndian.PutUint16(b[3:], o.EthAddressOffset)
+	b[5] = o.EthAddressInstructionIndex
+	binary.LittleEndian.PutUint16(b[6:], o.MessageDataOffset)
+	binary.LittleEndian.PutUint16(b[8:], o.MessageDataSize)
+	b[10] = o.MessageInstructionIndex
+}
+
+func parseSignatureOffsets(b []byte) SignatureOffsets {
+	return SignatureOffsets{
+		SignatureOffset:            binary.LittleEndian.Uint16(b[0:]),
+		SignatureInstructionIndex:  b[2],
+		EthAddressOffset:           binary.LittleEndian.Uint16(b[3:]),
+		EthAddressInstructionIndex: b[5],
+		MessageDataOffset:          binary.LittleEndian.Uint16(b[6:]),
+		MessageDataSize:            binary.LittleEndian.Uint16(b[8:]),
+		MessageInstructionIndex:    b[10],
+	}
+}
@@ -0,0 +1,82 @@
+package secp256k1prog
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthAddressFromPublicKey(t *testing.T) {
+	// the public key of private key 1
+	pubkey, _ := hex.DecodeString("0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+	address, err := EthAddressFromPublicKey(pubkey)
+	assert.Nil(t, err)
+	assert.Equal(t, "7e5f4552091a69125d5dfcb7b8c2659029395bdf", hex.EncodeToString(address[:]))
+
+	got, err := EthAddressFromPublicKey(pubkey[1:])
+	assert.Nil(t, err)
+	assert.Equal(t, address, got)
+
+	_, err = EthAddressFromPublicKey(pubkey[:33])
+	assert.NotNil(t, err)
+}
+
+func TestNewVerifyInstruction(t *testing.T) {
+	v := Verification{
+		EthAddress: EthAddress{1, 2, 3},
+		Signature:  bytes.Repeat([]byte{9}, SignatureSize),
+		RecoveryID: 1,
+		Message:    []byte("hello"),
+	}
+	instruction, err := NewVerifyInstruction([]Verification{v}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, common.Secp256k1ProgramID, instruction.ProgramID)
+
+	// the layout of a single signature is the one of @solana/web3.js
+	assert.Equal(t, uint8(1), instruction.Data[0])
+	assert.Equal(t, SignatureOffsets{
+		SignatureOffset:   32,
+		EthAddressOffset:  12,
+		MessageDataOffset: 97,
+		MessageDataSize:   5,
+	}, parseSignatureOffsets(instruction.Data[1:]))
+	assert.Equal(t, 12+20+64+1+5, len(instruction.Data))
+
+	_, err = NewVerifyInstruction([]Verification{{Signature: []byte{1}}}, 0)
+	assert.NotNil(t, err)
+}
+
+func TestParseVerifyInstruction(t *testing.T) {
+	verifications := []Verification{
+		{EthAddress: EthAddress{1}, Signature: bytes.Repeat([]byte{1}, SignatureSize), RecoveryID: 0, Message: []byte("alice")},
+		{EthAddress: EthAddress{2}, Signature: bytes.Repeat([]byte{2}, SignatureSize), RecoveryID: 1, Message: []byte("bob")},
+	}
+	feePayer := types.NewAccount().PublicKey
+	transfer := types.Instruction{
+		ProgramID: common.SystemProgramID,
+		Accounts:  []types.AccountMeta{{PubKey: feePayer, IsSigner: true, IsWritable: true}},
+		Data:      []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+	}
+	// the verify instruction is the second one
+	instruction, err := NewVerifyInstruction(verifications, 1)
+	assert.Nil(t, err)
+	message := types.NewMessage(feePayer, []types.Instruction{transfer, instruction}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+
+	got, err := ParseVerifyInstruction(message, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, verifications, got)
+
+	_, err = ParseVerifyInstruction(message, 0)
+	assert.NotNil(t, err)
+
+	// the offsets refer to the first instruction by mistake
+	instruction, err = NewVerifyInstruction(verifications, 0)
+	assert.Nil(t, err)
+	message = types.NewMessage(feePayer, []types.Instruction{transfer, instruction}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+	_, err = ParseVerifyInstruction(message, 1)
+	assert.ErrorIs(t, err, ErrInvalidInstructionData)
+}
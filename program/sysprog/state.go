@@ -25,6 +25,11 @@ func FeeCalculatorDeserialize(data []byte) (FeeCalculator, error) {
 
 const NonceAccountSize = 80
 
+const (
+	NonceAccountStateUninitialized uint32 = iota
+	NonceAccountStateInitialized
+)
+
 type NonceAccount struct {
 	Version          uint32
 	State            uint32
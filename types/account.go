@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
@@ -69,3 +70,21 @@ func AccountFromHex(key string) (Account, error) {
 func (a Account) Sign(message []byte) []byte {
 	return ed25519.Sign(a.PrivateKey, message)
 }
+
+// PubKey implements Signer
+func (a Account) PubKey() common.PublicKey {
+	return a.PublicKey
+}
+
+// SignMessage implements Signer
+func (a Account) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	return a.Sign(message), nil
+}
+
+// AccountFromSeed generate a account by a 32 bytes ed25519 seed, e.g. the first half of a keypair
+func AccountFromSeed(seed []byte) (Account, error) {
+	if len(seed) != ed25519.SeedSize {
+		return Account{}, fmt.Errorf("%w, expected: %v, got: %v", ErrAccountPrivateKeyLengthMismatch, ed25519.SeedSize, len(seed))
+	}
+	return AccountFromBytes(ed25519.NewKeyFromSeed(seed))
+}
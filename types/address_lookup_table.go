@@ -0,0 +1,9 @@
+package types
+
+import "github.com/36625090/solana-go/common"
+
+// AddressLookupTableAccount is an on-chain address lookup table, v0 messages load accounts from it by index
+type AddressLookupTableAccount struct {
+	Key       common.PublicKey
+	Addresses []common.PublicKey
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+)
+
+var (
+	ErrAccountFailedToJSONDecode = errors.New("failed to json decode")
+	ErrAccountPublicKeyMismatch  = errors.New("public key doesn't match the private key")
+)
+
+// AccountFromKeypairJSON generate a account by the json byte array format of solana-keygen, e.g. [12,34,...]
+func AccountFromKeypairJSON(data []byte) (Account, error) {
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return Account{}, fmt.Errorf("%w, err: %v", ErrAccountFailedToJSONDecode, err)
+	}
+	key := make([]byte, 0, len(values))
+	for _, v := range values {
+		if v < 0 || v > 255 {
+			return Account{}, fmt.Errorf("%w, %v is not a byte", ErrAccountFailedToJSONDecode, v)
+		}
+		key = append(key, byte(v))
+	}
+	account, err := AccountFromBytes(key)
+	if err != nil {
+		return Account{}, err
+	}
+	// the second half of a keypair file is the public key
+	derived := ed25519.NewKeyFromSeed(key[:ed25519.SeedSize])
+	if !bytes.Equal(derived, key) {
+		return Account{}, ErrAccountPublicKeyMismatch
+	}
+	return account, nil
+}
+
+// KeypairJSON encodes the account in the json byte array format of solana-keygen
+func (a Account) KeypairJSON() []byte {
+	b := make([]byte, 0, 4*len(a.PrivateKey)+2)
+	b = append(b, '[')
+	for i, v := range a.PrivateKey {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendUint(b, uint64(v), 10)
+	}
+	return append(b, ']')
+}
+
+// AccountFromKeypairFile reads a keypair file of solana-keygen, e.g. ~/.config/solana/id.json
+func AccountFromKeypairFile(path string) (Account, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Account{}, err
+	}
+	return AccountFromKeypairJSON(data)
+}
+
+// SaveKeypairFile writes the account to path in the keypair file format of solana-keygen, readable by the owner only
+func (a Account) SaveKeypairFile(path string) error {
+	return ioutil.WriteFile(path, a.KeypairJSON(), 0600)
+}
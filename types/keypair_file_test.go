@@ -0,0 +1,75 @@
+package types
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountFromKeypairJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Account
+		err  error
+	}{
+		{
+			data: "[214,49,53,208,232,140,85,41,45,128,173,3,79,105,136,236,132,164,35,93,59,196,51,59,127,139,1,155,245,83,230,184,21,109,62,131,66,207,210,237,39,93,125,50,137,69,236,28,138,68,1,30,175,228,109,140,77,52,105,79,223,111,131,31]",
+			want: Account{
+				PublicKey:  common.PublicKeyFromString("2SeBK1pUxnVbY82vN4TEJiWh4GwaGDkffxPegQP3DFPk"),
+				PrivateKey: []byte{214, 49, 53, 208, 232, 140, 85, 41, 45, 128, 173, 3, 79, 105, 136, 236, 132, 164, 35, 93, 59, 196, 51, 59, 127, 139, 1, 155, 245, 83, 230, 184, 21, 109, 62, 131, 66, 207, 210, 237, 39, 93, 125, 50, 137, 69, 236, 28, 138, 68, 1, 30, 175, 228, 109, 140, 77, 52, 105, 79, 223, 111, 131, 31},
+			},
+		},
+		{
+			name: "not a byte array",
+			data: `"5HNxRJoirY4oRTcRwiEYFALSSLn9nMAyLQKDuSuiCJ966816BjwGuamRdTLTsR2FBHiB7CQkGaw6B4ehBMogPRvW"`,
+			err:  ErrAccountFailedToJSONDecode,
+		},
+		{
+			name: "out of byte range",
+			data: "[256]",
+			err:  ErrAccountFailedToJSONDecode,
+		},
+		{
+			name: "short",
+			data: "[1,2,3]",
+			err:  ErrAccountPrivateKeyLengthMismatch,
+		},
+		{
+			name: "public key mismatch",
+			data: "[214,49,53,208,232,140,85,41,45,128,173,3,79,105,136,236,132,164,35,93,59,196,51,59,127,139,1,155,245,83,230,184,0,109,62,131,66,207,210,237,39,93,125,50,137,69,236,28,138,68,1,30,175,228,109,140,77,52,105,79,223,111,131,31]",
+			err:  ErrAccountPublicKeyMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AccountFromKeypairJSON([]byte(tt.data))
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+			if err == nil {
+				assert.Equal(t, tt.data, string(got.KeypairJSON()))
+			}
+		})
+	}
+}
+
+func TestAccount_SaveKeypairFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keypair")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "id.json")
+
+	account := NewAccount()
+	assert.Nil(t, account.SaveKeypairFile(path))
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	got, err := AccountFromKeypairFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, account, got)
+}
@@ -18,15 +18,56 @@ type MessageHeader struct {
 	NumReadonlyUnsignedAccounts uint8
 }
 
+// MessageVersion is the version of a message, the zero value is MessageVersionLegacy
+type MessageVersion string
+
+const (
+	MessageVersionLegacy MessageVersion = "legacy"
+	MessageVersionV0     MessageVersion = "v0"
+)
+
+// messageVersionPrefix is set on the first byte of versioned messages
+const messageVersionPrefix = 0x80
+
+var ErrLoadedAddressesMismatch = errors.New("loaded addresses don't match the address table lookups")
+
 type Message struct {
+	Version         MessageVersion
 	Header          MessageHeader
 	Accounts        []common.PublicKey
 	RecentBlockHash string
 	Instructions    []CompiledInstruction
+	// AddressLookupTables is only used by v0 messages
+	AddressLookupTables []CompiledAddressLookupTable
+}
+
+// CompiledAddressLookupTable loads accounts of a v0 message by their indexes in an address lookup table
+type CompiledAddressLookupTable struct {
+	AccountKey      common.PublicKey
+	WritableIndexes []uint8
+	ReadonlyIndexes []uint8
+}
+
+// LoadedAddresses are the accounts a v0 message loads from address lookup tables, in the order they are indexed after the static accounts
+type LoadedAddresses struct {
+	Writable []common.PublicKey
+	Readonly []common.PublicKey
+}
+
+// IsVersioned reports the message has a version prefix
+func (m *Message) IsVersioned() bool {
+	return m.Version != "" && m.Version != MessageVersionLegacy
 }
 
 func (m *Message) Serialize() ([]byte, error) {
 	b := []byte{}
+	switch m.Version {
+	case "", MessageVersionLegacy:
+	case MessageVersionV0:
+		b = append(b, messageVersionPrefix)
+	default:
+		return nil, fmt.Errorf("unsupported message version: %v", m.Version)
+	}
 	b = append(b, m.Header.NumRequireSignatures)
 	b = append(b, m.Header.NumReadonlySignedAccounts)
 	b = append(b, m.Header.NumReadonlyUnsignedAccounts)
@@ -53,32 +94,124 @@ func (m *Message) Serialize() ([]byte, error) {
 		b = append(b, bincode.UintToVarLenBytes(uint64(len(instruction.Data)))...)
 		b = append(b, instruction.Data...)
 	}
+
+	if !m.IsVersioned() {
+		return b, nil
+	}
+	b = append(b, bincode.UintToVarLenBytes(uint64(len(m.AddressLookupTables)))...)
+	for _, table := range m.AddressLookupTables {
+		b = append(b, table.AccountKey[:]...)
+		b = append(b, bincode.UintToVarLenBytes(uint64(len(table.WritableIndexes)))...)
+		b = append(b, table.WritableIndexes...)
+		b = append(b, bincode.UintToVarLenBytes(uint64(len(table.ReadonlyIndexes)))...)
+		b = append(b, table.ReadonlyIndexes...)
+	}
 	return b, nil
 }
 
+// DecompileInstructions restores the instructions of a legacy message or a v0 message without address table lookups.
+// It returns nil for a message with lookups or invalid account indexes, use DecompileInstructionsWithLoadedAddresses
+// to get the error.
 func (m *Message) DecompileInstructions() []Instruction {
+	instructions, err := m.DecompileInstructionsWithLoadedAddresses(LoadedAddresses{})
+	if err != nil {
+		return nil
+	}
+	return instructions
+}
+
+// DecompileInstructionsWithLoadedAddresses restores the instructions, the accounts loaded from address lookup tables
+// can be resolved by ResolveLoadedAddresses. It returns ErrLoadedAddressesMismatch if loaded doesn't fit the lookups.
+func (m *Message) DecompileInstructionsWithLoadedAddresses(loaded LoadedAddresses) ([]Instruction, error) {
+	var numWritable, numReadonly int
+	for _, lookup := range m.AddressLookupTables {
+		numWritable += len(lookup.WritableIndexes)
+		numReadonly += len(lookup.ReadonlyIndexes)
+	}
+	if len(loaded.Writable) != numWritable || len(loaded.Readonly) != numReadonly {
+		return nil, fmt.Errorf("%w, expect %d writable and %d readonly, got %d and %d", ErrLoadedAddressesMismatch, numWritable, numReadonly, len(loaded.Writable), len(loaded.Readonly))
+	}
+
+	keys := make([]common.PublicKey, 0, len(m.Accounts)+len(loaded.Writable)+len(loaded.Readonly))
+	keys = append(keys, m.Accounts...)
+	keys = append(keys, loaded.Writable...)
+	keys = append(keys, loaded.Readonly...)
+	numWritableLoaded := len(loaded.Writable)
+
+	isWritable := func(idx int) bool {
+		if idx >= len(m.Accounts) {
+			return idx < len(m.Accounts)+numWritableLoaded
+		}
+		return idx < int(m.Header.NumRequireSignatures-m.Header.NumReadonlySignedAccounts) ||
+			(idx >= int(m.Header.NumRequireSignatures) &&
+				idx < len(m.Accounts)-int(m.Header.NumReadonlyUnsignedAccounts))
+	}
+
 	instructions := make([]Instruction, 0, len(m.Instructions))
-	for _, cins := range m.Instructions {
+	for i, cins := range m.Instructions {
+		if cins.ProgramIDIndex >= len(keys) {
+			return nil, fmt.Errorf("instruction #%d program id index %d out of range", i+1, cins.ProgramIDIndex)
+		}
 		accounts := make([]AccountMeta, 0, len(cins.Accounts))
-		for i := 0; i < len(cins.Accounts); i++ {
+		for j := 0; j < len(cins.Accounts); j++ {
+			idx := cins.Accounts[j]
+			if idx >= len(keys) {
+				return nil, fmt.Errorf("instruction #%d account #%d index %d out of range", i+1, j+1, idx)
+			}
 			accounts = append(accounts, AccountMeta{
-				PubKey:   m.Accounts[cins.Accounts[i]],
-				IsSigner: cins.Accounts[i] < int(m.Header.NumRequireSignatures),
-				IsWritable: cins.Accounts[i] < int(m.Header.NumRequireSignatures-m.Header.NumReadonlySignedAccounts) ||
-					(cins.Accounts[i] >= int(m.Header.NumRequireSignatures) &&
-						cins.Accounts[i] < len(m.Accounts)-int(m.Header.NumReadonlyUnsignedAccounts)),
+				PubKey:     keys[idx],
+				IsSigner:   idx < int(m.Header.NumRequireSignatures),
+				IsWritable: isWritable(idx),
 			})
 		}
 		instructions = append(instructions, Instruction{
-			ProgramID: m.Accounts[cins.ProgramIDIndex],
+			ProgramID: keys[cins.ProgramIDIndex],
 			Accounts:  accounts,
 			Data:      cins.Data,
 		})
 	}
-	return instructions
+	return instructions, nil
+}
+
+// ResolveLoadedAddresses looks up the accounts loaded by the address table lookups of the message
+func (m *Message) ResolveLoadedAddresses(tables []AddressLookupTableAccount) (LoadedAddresses, error) {
+	addresses := make(map[common.PublicKey][]common.PublicKey, len(tables))
+	for _, table := range tables {
+		addresses[table.Key] = table.Addresses
+	}
+
+	loaded := LoadedAddresses{}
+	for _, lookup := range m.AddressLookupTables {
+		tableAddresses, ok := addresses[lookup.AccountKey]
+		if !ok {
+			return LoadedAddresses{}, fmt.Errorf("address lookup table %v not found", lookup.AccountKey.ToBase58())
+		}
+		for _, idx := range lookup.WritableIndexes {
+			if int(idx) >= len(tableAddresses) {
+				return LoadedAddresses{}, fmt.Errorf("index %d out of range of address lookup table %v", idx, lookup.AccountKey.ToBase58())
+			}
+			loaded.Writable = append(loaded.Writable, tableAddresses[idx])
+		}
+		for _, idx := range lookup.ReadonlyIndexes {
+			if int(idx) >= len(tableAddresses) {
+				return LoadedAddresses{}, fmt.Errorf("index %d out of range of address lookup table %v", idx, lookup.AccountKey.ToBase58())
+			}
+			loaded.Readonly = append(loaded.Readonly, tableAddresses[idx])
+		}
+	}
+	return loaded, nil
 }
 
 func MessageDeserialize(messageData []byte) (Message, error) {
+	var version MessageVersion
+	if len(messageData) > 0 && messageData[0]&messageVersionPrefix != 0 {
+		if v := messageData[0] &^ messageVersionPrefix; v != 0 {
+			return Message{}, fmt.Errorf("unsupported message version: %d", v)
+		}
+		version = MessageVersionV0
+		messageData = messageData[1:]
+	}
+
 	var numRequireSignatures, numReadonlySignedAccounts, numReadonlyUnsignedAccounts uint8
 	var t uint64
 	var err error
@@ -137,6 +270,9 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		if err != nil {
 			return Message{}, fmt.Errorf("parse instruction #%d data length error: %v", i+1, err)
 		}
+		if uint64(len(messageData)) < dataLen {
+			return Message{}, fmt.Errorf("parse instruction #%d data error", i+1)
+		}
 		var data []byte
 		data, messageData = messageData[:dataLen], messageData[dataLen:]
 
@@ -147,18 +283,56 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		})
 	}
 
+	var lookups []CompiledAddressLookupTable
+	if version == MessageVersionV0 {
+		lookups, err = parseAddressLookupTables(&messageData)
+		if err != nil {
+			return Message{}, err
+		}
+	}
+
 	return Message{
+		Version: version,
 		Header: MessageHeader{
 			NumRequireSignatures:        numRequireSignatures,
 			NumReadonlySignedAccounts:   numReadonlySignedAccounts,
 			NumReadonlyUnsignedAccounts: numReadonlyUnsignedAccounts,
 		},
-		Accounts:        accounts,
-		RecentBlockHash: blockHash,
-		Instructions:    instructions,
+		Accounts:            accounts,
+		RecentBlockHash:     blockHash,
+		Instructions:        instructions,
+		AddressLookupTables: lookups,
 	}, nil
 }
 
+func parseAddressLookupTables(messageData *[]byte) ([]CompiledAddressLookupTable, error) {
+	count, err := parseUvarint(messageData)
+	if err != nil {
+		return nil, fmt.Errorf("parse address table lookup count error: %v", err)
+	}
+	lookups := make([]CompiledAddressLookupTable, 0, count)
+	for i := 0; i < int(count); i++ {
+		if len(*messageData) < 32 {
+			return nil, fmt.Errorf("parse address table lookup #%d account error", i+1)
+		}
+		lookup := CompiledAddressLookupTable{AccountKey: common.PublicKeyFromBytes((*messageData)[:32])}
+		*messageData = (*messageData)[32:]
+		for _, indexes := range []*[]uint8{&lookup.WritableIndexes, &lookup.ReadonlyIndexes} {
+			n, err := parseUvarint(messageData)
+			if err != nil {
+				return nil, fmt.Errorf("parse address table lookup #%d index count error: %v", i+1, err)
+			}
+			if uint64(len(*messageData)) < n {
+				return nil, fmt.Errorf("parse address table lookup #%d indexes error", i+1)
+			}
+			*indexes = append([]uint8{}, (*messageData)[:n]...)
+			*messageData = (*messageData)[n:]
+		}
+		lookups = append(lookups, lookup)
+	}
+	return lookups, nil
+}
+
 func MustMessageDeserialize(messageData []byte) Message {
 	message, err := MessageDeserialize(messageData)
 	if err != nil {
@@ -168,8 +342,68 @@ func MustMessageDeserialize(messageData []byte) Message {
 }
 
 func NewMessage(feePayer common.PublicKey, instructions []Instruction, recentBlockHash string) Message {
+	accountMap, _ := collectAccountMetas(instructions)
+	header, publicKeys := orderAccounts(feePayer, accountMap)
+	return Message{
+		Header:          header,
+		Accounts:        publicKeys,
+		RecentBlockHash: recentBlockHash,
+		Instructions:    compileInstructions(instructions, publicKeys),
+	}
+}
+
+// NewMessageV0 creates a v0 message. The accounts which are neither signers nor invoked programs are loaded
+// from the address lookup tables if they are in one, the tables are searched in order.
+func NewMessageV0(feePayer common.PublicKey, instructions []Instruction, recentBlockHash string, addressLookupTables []AddressLookupTableAccount) Message {
+	accountMap, programIDs := collectAccountMetas(instructions)
+
+	lookups := []CompiledAddressLookupTable{}
+	loaded := LoadedAddresses{}
+	for _, table := range addressLookupTables {
+		lookup := CompiledAddressLookupTable{AccountKey: table.Key}
+		for idx, address := range table.Addresses {
+			if idx > 255 {
+				break
+			}
+			account, exist := accountMap[address]
+			if !exist || account.IsSigner || programIDs[address] || address == feePayer {
+				continue
+			}
+			if account.IsWritable {
+				lookup.WritableIndexes = append(lookup.WritableIndexes, uint8(idx))
+				loaded.Writable = append(loaded.Writable, address)
+			} else {
+				lookup.ReadonlyIndexes = append(lookup.ReadonlyIndexes, uint8(idx))
+				loaded.Readonly = append(loaded.Readonly, address)
+			}
+			delete(accountMap, address)
+		}
+		if len(lookup.WritableIndexes) > 0 || len(lookup.ReadonlyIndexes) > 0 {
+			lookups = append(lookups, lookup)
+		}
+	}
+
+	header, publicKeys := orderAccounts(feePayer, accountMap)
+	keys := make([]common.PublicKey, 0, len(publicKeys)+len(loaded.Writable)+len(loaded.Readonly))
+	keys = append(keys, publicKeys...)
+	keys = append(keys, loaded.Writable...)
+	keys = append(keys, loaded.Readonly...)
+	return Message{
+		Version:             MessageVersionV0,
+		Header:              header,
+		Accounts:            publicKeys,
+		RecentBlockHash:     recentBlockHash,
+		Instructions:        compileInstructions(instructions, keys),
+		AddressLookupTables: lookups,
+	}
+}
+
+// collectAccountMetas merges the accounts of the instructions, it also returns the invoked programs
+func collectAccountMetas(instructions []Instruction) (map[common.PublicKey]*AccountMeta, map[common.PublicKey]bool) {
 	accountMap := map[common.PublicKey]*AccountMeta{}
+	programIDs := map[common.PublicKey]bool{}
 	for _, instruction := range instructions {
+		programIDs[instruction.ProgramID] = true
 		// program is a readonly unsigned account
 		_, exist := accountMap[instruction.ProgramID]
 		if !exist {
@@ -190,7 +424,11 @@ func NewMessage(feePayer common.PublicKey, instructions []Instruction, recentBlo
 			}
 		}
 	}
+	return accountMap, programIDs
+}
 
+// orderAccounts sorts the accounts into writable signers, readonly signers, writable and readonly non-signers, the fee payer goes first
+func orderAccounts(feePayer common.PublicKey, accountMap map[common.PublicKey]*AccountMeta) (MessageHeader, []common.PublicKey) {
 	writableSignedAccount := []common.PublicKey{}
 	readOnlySignedAccount := []common.PublicKey{}
 	writableUnsignedAccount := []common.PublicKey{}
@@ -245,6 +483,15 @@ func NewMessage(feePayer common.PublicKey, instructions []Instruction, recentBlo
 	publicKeys = append(publicKeys, readOnlySignedAccount...)
 	publicKeys = append(publicKeys, writableUnsignedAccount...)
 	publicKeys = append(publicKeys, readOnlyUnsignedAccount...)
+
+	return MessageHeader{
+		NumRequireSignatures:        uint8(len(writableSignedAccount) + len(readOnlySignedAccount)),
+		NumReadonlySignedAccounts:   uint8(len(readOnlySignedAccount)),
+		NumReadonlyUnsignedAccounts: uint8(len(readOnlyUnsignedAccount)),
+	}, publicKeys
+}
+
+func compileInstructions(instructions []Instruction, publicKeys []common.PublicKey) []CompiledInstruction {
 	publicKeyToIdx := map[common.PublicKey]int{}
 	for idx, publicKey := range publicKeys {
 		publicKeyToIdx[publicKey] = idx
@@ -262,15 +509,5 @@ func NewMessage(feePayer common.PublicKey, instructions []Instruction, recentBlo
 			Data:           instruction.Data,
 		})
 	}
-
-	return Message{
-		Header: MessageHeader{
-			NumRequireSignatures:        uint8(len(writableSignedAccount) + len(readOnlySignedAccount)),
-			NumReadonlySignedAccounts:   uint8(len(readOnlySignedAccount)),
-			NumReadonlyUnsignedAccounts: uint8(len(readOnlyUnsignedAccount)),
-		},
-		Accounts:        publicKeys,
-		RecentBlockHash: recentBlockHash,
-		Instructions:    compiledInstructions,
-	}
+	return compiledInstructions
 }
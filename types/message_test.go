@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/36625090/solana-go/common"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMessage_Serialize(t *testing.T) {
@@ -124,3 +125,66 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMessageV0(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	readonly := common.PublicKeyFromString("SysvarRent111111111111111111111111111111111")
+	table := AddressLookupTableAccount{
+		Key: common.PublicKeyFromString("9qERNBLXzCqchyfquh2DjUT21xsLym6ynZPRh9TZbEiq"),
+		Addresses: []common.PublicKey{
+			common.SystemProgramID,
+			readonly,
+			feePayer,
+			to,
+		},
+	}
+	instructions := []Instruction{
+		{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer, IsSigner: true, IsWritable: true},
+				{PubKey: to, IsSigner: false, IsWritable: true},
+				{PubKey: readonly, IsSigner: false, IsWritable: false},
+			},
+			Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	message := NewMessageV0(feePayer, instructions, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5", []AddressLookupTableAccount{table})
+	assert.Equal(t, MessageVersionV0, message.Version)
+	assert.Equal(t, MessageHeader{NumRequireSignatures: 1, NumReadonlySignedAccounts: 0, NumReadonlyUnsignedAccounts: 1}, message.Header)
+	// the invoked program and the signer stay static
+	assert.Equal(t, []common.PublicKey{feePayer, common.SystemProgramID}, message.Accounts)
+	assert.Equal(t, []CompiledAddressLookupTable{
+		{AccountKey: table.Key, WritableIndexes: []uint8{3}, ReadonlyIndexes: []uint8{1}},
+	}, message.AddressLookupTables)
+	assert.Equal(t, []CompiledInstruction{
+		{ProgramIDIndex: 1, Accounts: []int{0, 2, 3}, Data: instructions[0].Data},
+	}, message.Instructions)
+
+	b, err := message.Serialize()
+	assert.Nil(t, err)
+	assert.Equal(t, byte(0x80), b[0])
+	deserialized, err := MessageDeserialize(b)
+	assert.Nil(t, err)
+	assert.Equal(t, message, deserialized)
+
+	loaded, err := deserialized.ResolveLoadedAddresses([]AddressLookupTableAccount{table})
+	assert.Nil(t, err)
+	assert.Equal(t, LoadedAddresses{Writable: []common.PublicKey{to}, Readonly: []common.PublicKey{readonly}}, loaded)
+	decompiled, err := deserialized.DecompileInstructionsWithLoadedAddresses(loaded)
+	assert.Nil(t, err)
+	assert.Equal(t, instructions, decompiled)
+
+	_, err = deserialized.ResolveLoadedAddresses(nil)
+	assert.EqualError(t, err, "address lookup table 9qERNBLXzCqchyfquh2DjUT21xsLym6ynZPRh9TZbEiq not found")
+	_, err = deserialized.DecompileInstructionsWithLoadedAddresses(LoadedAddresses{})
+	assert.ErrorIs(t, err, ErrLoadedAddressesMismatch)
+	assert.Nil(t, deserialized.DecompileInstructions())
+}
+
+func TestMessageDeserializeUnsupportedVersion(t *testing.T) {
+	_, err := MessageDeserialize([]byte{0x81, 1, 0, 1})
+	assert.EqualError(t, err, "unsupported message version: 1")
+}
@@ -0,0 +1,183 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/36625090/solana-go/common"
+)
+
+// OffchainMessageSigningDomain prefixes every off-chain message so it can't be a valid transaction message
+var OffchainMessageSigningDomain = []byte("\xffsolana offchain")
+
+const (
+	// OffchainMessageHeaderSize is the size of the signing domain, version, format and length
+	OffchainMessageHeaderSize = 16 + 1 + 1 + 2
+	// OffchainMessageMaxLedgerSize is the max message size which a ledger can display
+	OffchainMessageMaxLedgerSize = PacketDataSize - OffchainMessageHeaderSize
+	// OffchainMessageMaxSize is the max message size
+	OffchainMessageMaxSize = 65535 - OffchainMessageHeaderSize
+)
+
+type OffchainMessageFormat uint8
+
+const (
+	// OffchainMessageFormatRestrictedASCII is printable ascii up to OffchainMessageMaxLedgerSize
+	OffchainMessageFormatRestrictedASCII OffchainMessageFormat = iota
+	// OffchainMessageFormatLimitedUTF8 is utf8 up to OffchainMessageMaxLedgerSize
+	OffchainMessageFormatLimitedUTF8
+	// OffchainMessageFormatExtendedUTF8 is utf8 up to OffchainMessageMaxSize
+	OffchainMessageFormatExtendedUTF8
+)
+
+var (
+	ErrOffchainMessageTooLong            = errors.New("off-chain message too long")
+	ErrOffchainMessageInvalidEncoding    = errors.New("off-chain message is not utf8")
+	ErrOffchainMessageUnsupportedVersion = errors.New("unsupported off-chain message version")
+	ErrOffchainMessageInvalid            = errors.New("invalid off-chain message")
+)
+
+// OffchainMessage is a message signed outside of a transaction, in the format of `solana sign-offchain-message`
+type OffchainMessage struct {
+	Version uint8
+	Format  OffchainMessageFormat
+	Message []byte
+}
+
+// NewOffchainMessage picks the most restricted format the message fits in
+func NewOffchainMessage(message []byte) (OffchainMessage, error) {
+	var format OffchainMessageFormat
+	switch {
+	case len(message) > OffchainMessageMaxSize:
+		return OffchainMessage{}, fmt.Errorf("%w, max: %v, got: %v", ErrOffchainMessageTooLong, OffchainMessageMaxSize, len(message))
+	case !utf8.Valid(message):
+		return OffchainMessage{}, ErrOffchainMessageInvalidEncoding
+	case len(message) > OffchainMessageMaxLedgerSize:
+		format = OffchainMessageFormatExtendedUTF8
+	case isPrintableASCII(message):
+		format = OffchainMessageFormatRestrictedASCII
+	default:
+		format = OffchainMessageFormatLimitedUTF8
+	}
+	return OffchainMessage{
+		Version: 0,
+		Format:  format,
+		Message: message,
+	}, nil
+}
+
+// Serialize returns the bytes to be signed
+func (m OffchainMessage) Serialize() ([]byte, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	b := make([]byte, 0, OffchainMessageHeaderSize+len(m.Message))
+	b = append(b, OffchainMessageSigningDomain...)
+	b = append(b, m.Version, uint8(m.Format))
+	b = append(b, 0, 0)
+	binary.LittleEndian.PutUint16(b[len(b)-2:], uint16(len(m.Message)))
+	return append(b, m.Message...), nil
+}
+
+// OffchainMessageDeserialize parses a serialized off-chain message
+func OffchainMessageDeserialize(data []byte) (OffchainMessage, error) {
+	if len(data) < OffchainMessageHeaderSize || !bytes.HasPrefix(data, OffchainMessageSigningDomain) {
+		return OffchainMessage{}, fmt.Errorf("%w, no signing domain", ErrOffchainMessageInvalid)
+	}
+	data = data[len(OffchainMessageSigningDomain):]
+	m := OffchainMessage{
+		Version: data[0],
+		Format:  OffchainMessageFormat(data[1]),
+	}
+	length := int(binary.LittleEndian.Uint16(data[2:4]))
+	data = data[4:]
+	if len(data) != length {
+		return OffchainMessage{}, fmt.Errorf("%w, length expected: %v, got: %v", ErrOffchainMessageInvalid, length, len(data))
+	}
+	m.Message = data
+	if err := m.validate(); err != nil {
+		return OffchainMessage{}, err
+	}
+	return m, nil
+}
+
+// Sign signs the serialized message by signer
+func (m OffchainMessage) Sign(ctx context.Context, signer Signer) (Signature, error) {
+	data, err := m.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return signMessage(ctx, signer, data)
+}
+
+// Verify reports whether signature is the one of the message by pubkey
+func (m OffchainMessage) Verify(pubkey common.PublicKey, signature []byte) (bool, error) {
+	data, err := m.Serialize()
+	if err != nil {
+		return false, err
+	}
+	return len(signature) == ed25519.SignatureSize && ed25519.Verify(pubkey.Bytes(), data, signature), nil
+}
+
+// SignOffchainMessage signs message as an off-chain message
+func (a Account) SignOffchainMessage(message []byte) (Signature, error) {
+	m, err := NewOffchainMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	return m.Sign(context.Background(), a)
+}
+
+// VerifyOffchainMessage reports whether signature is the one of message signed as an off-chain message by pubkey
+func VerifyOffchainMessage(pubkey common.PublicKey, message, signature []byte) (bool, error) {
+	m, err := NewOffchainMessage(message)
+	if err != nil {
+		return false, err
+	}
+	return m.Verify(pubkey, signature)
+}
+
+func (m OffchainMessage) validate() error {
+	if m.Version != 0 {
+		return fmt.Errorf("%w, %v", ErrOffchainMessageUnsupportedVersion, m.Version)
+	}
+	maxSize := OffchainMessageMaxLedgerSize
+	switch m.Format {
+	case OffchainMessageFormatRestrictedASCII:
+		if !isPrintableASCII(m.Message) {
+			return fmt.Errorf("%w, not printable ascii", ErrOffchainMessageInvalid)
+		}
+	case OffchainMessageFormatLimitedUTF8:
+		if !utf8.Valid(m.Message) {
+			return ErrOffchainMessageInvalidEncoding
+		}
+	case OffchainMessageFormatExtendedUTF8:
+		if !utf8.Valid(m.Message) {
+			return ErrOffchainMessageInvalidEncoding
+		}
+		maxSize = OffchainMessageMaxSize
+	default:
+		return fmt.Errorf("%w, unknown format %v", ErrOffchainMessageInvalid, m.Format)
+	}
+	if len(m.Message) == 0 {
+		return fmt.Errorf("%w, empty message", ErrOffchainMessageInvalid)
+	}
+	if len(m.Message) > maxSize {
+		return fmt.Errorf("%w, max: %v, got: %v", ErrOffchainMessageTooLong, maxSize, len(m.Message))
+	}
+	return nil
+}
+
+func isPrintableASCII(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffchainMessage_Serialize(t *testing.T) {
+	m, err := NewOffchainMessage([]byte("Test Message"))
+	assert.Nil(t, err)
+	assert.Equal(t, OffchainMessageFormatRestrictedASCII, m.Format)
+	data, err := m.Serialize()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{255, 115, 111, 108, 97, 110, 97, 32, 111, 102, 102, 99, 104, 97, 105, 110, 0, 0, 12, 0, 84, 101, 115, 116, 32, 77, 101, 115, 115, 97, 103, 101}, data)
+
+	got, err := OffchainMessageDeserialize(data)
+	assert.Nil(t, err)
+	assert.Equal(t, m, got)
+
+	_, err = OffchainMessageDeserialize(data[:len(data)-1])
+	assert.True(t, errors.Is(err, ErrOffchainMessageInvalid))
+	_, err = OffchainMessageDeserialize(data[1:])
+	assert.True(t, errors.Is(err, ErrOffchainMessageInvalid))
+}
+
+func TestNewOffchainMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message []byte
+		format  OffchainMessageFormat
+		err     error
+	}{
+		{name: "ascii", message: []byte("hello"), format: OffchainMessageFormatRestrictedASCII},
+		{name: "utf8", message: []byte("héllo\n"), format: OffchainMessageFormatLimitedUTF8},
+		{name: "extended", message: bytes.Repeat([]byte("a"), OffchainMessageMaxLedgerSize+1), format: OffchainMessageFormatExtendedUTF8},
+		{name: "too long", message: bytes.Repeat([]byte("a"), OffchainMessageMaxSize+1), err: ErrOffchainMessageTooLong},
+		{name: "not utf8", message: []byte{0xff, 0xfe}, err: ErrOffchainMessageInvalidEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewOffchainMessage(tt.message)
+			assert.ErrorIs(t, err, tt.err)
+			if err == nil {
+				assert.Equal(t, tt.format, m.Format)
+			}
+		})
+	}
+}
+
+func TestAccount_SignOffchainMessage(t *testing.T) {
+	account := NewAccount()
+	sig, err := account.SignOffchainMessage([]byte("Test Message"))
+	assert.Nil(t, err)
+
+	ok, err := VerifyOffchainMessage(account.PublicKey, []byte("Test Message"), sig)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = VerifyOffchainMessage(account.PublicKey, []byte("Test Message!"), sig)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	// the raw message signature is not an off-chain message signature
+	ok, err = VerifyOffchainMessage(account.PublicKey, []byte("Test Message"), account.Sign([]byte("Test Message")))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	m, _ := NewOffchainMessage([]byte("Test Message"))
+	signed, err := m.Sign(context.Background(), account)
+	assert.Nil(t, err)
+	assert.Equal(t, sig, signed)
+}
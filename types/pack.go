@@ -0,0 +1,92 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/36625090/solana-go/pkg/bincode"
+)
+
+var ErrInstructionGroupTooLarge = errors.New("instruction group doesn't fit in a transaction")
+
+// TransactionSize returns the size of the serialized transaction of the message once it's signed
+func (m Message) TransactionSize() (int, error) {
+	data, err := m.Serialize()
+	if err != nil {
+		return 0, err
+	}
+	numSignatures := int(m.Header.NumRequireSignatures)
+	return len(bincode.UintToVarLenBytes(uint64(numSignatures))) + numSignatures*64 + len(data), nil
+}
+
+// EstimateTransactionSize returns the size of the serialized transaction made of the instructions
+func EstimateTransactionSize(feePayer common.PublicKey, instructions []Instruction) (int, error) {
+	// the blockhash has the same size whatever it is
+	return NewMessage(feePayer, instructions, common.PublicKey{}.ToBase58()).TransactionSize()
+}
+
+type PackInstructionsParam struct {
+	FeePayer common.PublicKey
+	// InstructionGroups are packed in order, the instructions of a group always land in the same transaction,
+	// e.g. creating an account and initializing it
+	InstructionGroups [][]Instruction
+	// MaxSize is the max size of a serialized transaction. default: PacketDataSize
+	MaxSize int
+	// MaxSigners is the max number of signers of a transaction, including the fee payer. 0 means no limit
+	MaxSigners int
+}
+
+// PackInstructions splits the instruction groups into as few transactions as possible without reordering them,
+// it returns the instructions of each transaction.
+func PackInstructions(param PackInstructionsParam) ([][]Instruction, error) {
+	if param.MaxSize <= 0 {
+		param.MaxSize = PacketDataSize
+	}
+
+	packed := [][]Instruction{}
+	current := []Instruction{}
+	for i, group := range param.InstructionGroups {
+		if len(group) == 0 {
+			continue
+		}
+		candidate := append(append(make([]Instruction, 0, len(current)+len(group)), current...), group...)
+		fit, err := param.fit(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if fit {
+			current = candidate
+			continue
+		}
+		if len(current) == 0 {
+			return nil, fmt.Errorf("%w, group %v", ErrInstructionGroupTooLarge, i)
+		}
+
+		packed = append(packed, current)
+		current = append([]Instruction{}, group...)
+		fit, err = param.fit(current)
+		if err != nil {
+			return nil, err
+		}
+		if !fit {
+			return nil, fmt.Errorf("%w, group %v", ErrInstructionGroupTooLarge, i)
+		}
+	}
+	if len(current) > 0 {
+		packed = append(packed, current)
+	}
+	return packed, nil
+}
+
+func (p PackInstructionsParam) fit(instructions []Instruction) (bool, error) {
+	message := NewMessage(p.FeePayer, instructions, common.PublicKey{}.ToBase58())
+	if p.MaxSigners > 0 && int(message.Header.NumRequireSignatures) > p.MaxSigners {
+		return false, nil
+	}
+	size, err := message.TransactionSize()
+	if err != nil {
+		return false, err
+	}
+	return size <= p.MaxSize, nil
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func transferInstruction(from, to common.PublicKey) Instruction {
+	return Instruction{
+		ProgramID: common.SystemProgramID,
+		Accounts: []AccountMeta{
+			{PubKey: from, IsSigner: true, IsWritable: true},
+			{PubKey: to, IsSigner: false, IsWritable: true},
+		},
+		Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+	}
+}
+
+func TestMessage_TransactionSize(t *testing.T) {
+	feePayer := NewAccount()
+	alice := NewAccount()
+	instructions := []Instruction{
+		transferInstruction(feePayer.PublicKey, NewAccount().PublicKey),
+		transferInstruction(alice.PublicKey, NewAccount().PublicKey),
+	}
+
+	size, err := EstimateTransactionSize(feePayer.PublicKey, instructions)
+	assert.Nil(t, err)
+
+	raw, err := CreateRawTransaction(CreateRawTransactionParam{
+		Instructions:    instructions,
+		Signers:         []Account{feePayer, alice},
+		FeePayer:        feePayer.PublicKey,
+		RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, len(raw), size)
+}
+
+func TestPackInstructions(t *testing.T) {
+	feePayer := NewAccount().PublicKey
+	groups := [][]Instruction{}
+	for i := 0; i < 100; i++ {
+		groups = append(groups, []Instruction{transferInstruction(feePayer, NewAccount().PublicKey)})
+	}
+	// an atomic pair
+	alice := NewAccount().PublicKey
+	groups = append(groups, []Instruction{
+		transferInstruction(feePayer, alice),
+		transferInstruction(alice, NewAccount().PublicKey),
+	})
+
+	packed, err := PackInstructions(PackInstructionsParam{FeePayer: feePayer, InstructionGroups: groups})
+	assert.Nil(t, err)
+
+	count := 0
+	for i, instructions := range packed {
+		size, err := EstimateTransactionSize(feePayer, instructions)
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, size, PacketDataSize)
+		// the next instruction didn't fit
+		if i+1 < len(packed) {
+			size, err = EstimateTransactionSize(feePayer, append(append([]Instruction{}, instructions...), packed[i+1][0]))
+			assert.Nil(t, err)
+			assert.Greater(t, size, PacketDataSize)
+		}
+		count += len(instructions)
+	}
+	assert.Equal(t, 102, count)
+	last := packed[len(packed)-1]
+	assert.Equal(t, groups[100], last[len(last)-2:])
+
+	// bob's transfer would make a third signer
+	bob := []Instruction{transferInstruction(NewAccount().PublicKey, NewAccount().PublicKey)}
+	packed, err = PackInstructions(PackInstructionsParam{FeePayer: feePayer, InstructionGroups: append(groups[99:], bob), MaxSigners: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]Instruction{append(append([]Instruction{}, groups[99]...), groups[100]...), bob}, packed)
+
+	_, err = PackInstructions(PackInstructionsParam{FeePayer: feePayer, InstructionGroups: groups[:2], MaxSize: 100})
+	assert.True(t, errors.Is(err, ErrInstructionGroupTooLarge))
+}
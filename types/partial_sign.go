@@ -0,0 +1,113 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/36625090/solana-go/common"
+)
+
+var (
+	ErrTransactionMessageMismatch   = errors.New("message mismatch")
+	ErrTransactionSignatureConflict = errors.New("signature conflict")
+)
+
+var emptySignature = make([]byte, 64)
+
+// PartialSign adds the signatures of signers and leaves the others as they are, the missing ones are
+// filled with placeholders so the transaction can be serialized and passed to the next party.
+func (tx *Transaction) PartialSign(ctx context.Context, signers []Signer) error {
+	tx.fillSignatures()
+
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	for _, signer := range signers {
+		idx := tx.signerIndex(signer.PubKey())
+		if idx < 0 {
+			return fmt.Errorf("%w, %v is not a signer", ErrTransactionAddNotNecessarySignatures, signer.PubKey())
+		}
+		sig, err := signMessage(ctx, signer, data)
+		if err != nil {
+			return err
+		}
+		tx.Signatures[idx] = sig
+	}
+	return nil
+}
+
+// MissingSigners returns the signers whose signature is not present, in the order of the message
+func (tx Transaction) MissingSigners() []common.PublicKey {
+	missing := []common.PublicKey{}
+	for i := 0; i < int(tx.Message.Header.NumRequireSignatures) && i < len(tx.Message.Accounts); i++ {
+		if i >= len(tx.Signatures) || isEmptySignature(tx.Signatures[i]) {
+			missing = append(missing, tx.Message.Accounts[i])
+		}
+	}
+	return missing
+}
+
+// IsFullySigned reports whether all required signatures are present
+func (tx Transaction) IsFullySigned() bool {
+	return len(tx.MissingSigners()) == 0
+}
+
+// MergeSignatures copies the signatures of others, which must carry the same message, into tx.
+// A signer with different signatures in tx and others is an ErrTransactionSignatureConflict.
+func (tx *Transaction) MergeSignatures(others ...Transaction) error {
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	tx.fillSignatures()
+
+	for _, other := range others {
+		otherData, err := other.Message.Serialize()
+		if err != nil {
+			return fmt.Errorf("failed to serialize message, err: %v", err)
+		}
+		if !bytes.Equal(data, otherData) {
+			return ErrTransactionMessageMismatch
+		}
+		for i, sig := range other.Signatures {
+			if i >= len(tx.Signatures) || isEmptySignature(sig) {
+				continue
+			}
+			if !isEmptySignature(tx.Signatures[i]) && !bytes.Equal(tx.Signatures[i], sig) {
+				return fmt.Errorf("%w, %v", ErrTransactionSignatureConflict, tx.Message.Accounts[i])
+			}
+			tx.Signatures[i] = sig
+		}
+	}
+	return nil
+}
+
+// fillSignatures makes a signature slot with a placeholder for each required signer
+func (tx *Transaction) fillSignatures() {
+	n := int(tx.Message.Header.NumRequireSignatures)
+	signatures := make([]Signature, 0, n)
+	for i := 0; i < n; i++ {
+		if i < len(tx.Signatures) && !isEmptySignature(tx.Signatures[i]) {
+			signatures = append(signatures, tx.Signatures[i])
+			continue
+		}
+		signatures = append(signatures, make([]byte, 64))
+	}
+	tx.Signatures = signatures
+}
+
+func (tx *Transaction) signerIndex(pubkey common.PublicKey) int {
+	for i := 0; i < int(tx.Message.Header.NumRequireSignatures) && i < len(tx.Message.Accounts); i++ {
+		if tx.Message.Accounts[i] == pubkey {
+			return i
+		}
+	}
+	return -1
+}
+
+func isEmptySignature(sig Signature) bool {
+	return len(sig) == 0 || bytes.Equal(sig, emptySignature)
+}
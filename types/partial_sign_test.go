@@ -0,0 +1,72 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartialSign(t *testing.T) {
+	feePayer := NewAccount()
+	alice := NewAccount()
+	bob := NewAccount()
+	message := NewMessage(feePayer.PublicKey, []Instruction{
+		{
+			ProgramID: common.PublicKeyFromString("CustomProgram111111111111111111111111111111"),
+			Accounts: []AccountMeta{
+				{PubKey: alice.PublicKey, IsSigner: true, IsWritable: true},
+				{PubKey: bob.PublicKey, IsSigner: true, IsWritable: false},
+			},
+			Data: []byte{},
+		},
+	}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+
+	want, err := NewTransaction(message, []Account{feePayer, alice, bob})
+	assert.Nil(t, err)
+
+	// the fee payer builds and signs first, then passes the serialized tx on
+	tx := Transaction{Message: message}
+	assert.Equal(t, []common.PublicKey{feePayer.PublicKey, alice.PublicKey, bob.PublicKey}, tx.MissingSigners())
+	assert.Nil(t, tx.PartialSign(context.Background(), []Signer{feePayer}))
+	assert.Equal(t, []common.PublicKey{alice.PublicKey, bob.PublicKey}, tx.MissingSigners())
+	raw, err := tx.Serialize()
+	assert.Nil(t, err)
+
+	// alice and bob sign their own copies
+	aliceTx, err := TransactionDeserialize(raw)
+	assert.Nil(t, err)
+	assert.Nil(t, aliceTx.PartialSign(context.Background(), []Signer{alice}))
+	bobTx, err := TransactionDeserialize(raw)
+	assert.Nil(t, err)
+	assert.Nil(t, bobTx.PartialSign(context.Background(), []Signer{bob}))
+
+	assert.Nil(t, tx.MergeSignatures(aliceTx, bobTx))
+	assert.True(t, tx.IsFullySigned())
+	assert.Equal(t, want.Signatures, tx.Signatures)
+
+	err = tx.PartialSign(context.Background(), []Signer{NewAccount()})
+	assert.True(t, errors.Is(err, ErrTransactionAddNotNecessarySignatures))
+
+	conflict := Transaction{Message: message}
+	conflict.Signatures = []Signature{make([]byte, 64), feePayer.Sign([]byte("other")), make([]byte, 64)}
+	err = tx.MergeSignatures(conflict)
+	assert.True(t, errors.Is(err, ErrTransactionSignatureConflict))
+
+	other := NewMessage(feePayer.PublicKey, nil, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+	err = tx.MergeSignatures(Transaction{Message: other})
+	assert.Equal(t, ErrTransactionMessageMismatch, err)
+}
+
+func TestTransaction_SerializeUnsigned(t *testing.T) {
+	feePayer := NewAccount()
+	tx := Transaction{Message: NewMessage(feePayer.PublicKey, nil, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")}
+	raw, err := tx.Serialize()
+	assert.Nil(t, err)
+
+	got, err := TransactionDeserialize(raw)
+	assert.Nil(t, err)
+	assert.Equal(t, []common.PublicKey{feePayer.PublicKey}, got.MissingSigners())
+}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"context"
+
+	"github.com/36625090/solana-go/common"
+)
+
+// Signer signs messages for the account of its public key. Account is the in-memory implementation,
+// others can keep the private key elsewhere, e.g. in a KMS or behind a remote signing service.
+type Signer interface {
+	PubKey() common.PublicKey
+	SignMessage(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// AccountSigners converts accounts to signers
+func AccountSigners(accounts []Account) []Signer {
+	signers := make([]Signer, 0, len(accounts))
+	for _, account := range accounts {
+		signers = append(signers, account)
+	}
+	return signers
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// remoteSigner signs by an account it doesn't expose, like a KMS backed signer
+type remoteSigner struct {
+	account Account
+	err     error
+}
+
+func (s remoteSigner) PubKey() common.PublicKey {
+	return s.account.PublicKey
+}
+
+func (s remoteSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.account.Sign(message), nil
+}
+
+func TestNewTransactionWithSigners(t *testing.T) {
+	feePayer := AccountFromPrivateKeyBytes([]byte{220, 190, 97, 243, 86, 180, 6, 192, 121, 120, 30, 246, 134, 81, 46, 27, 181, 181, 148, 200, 182, 184, 13, 124, 51, 186, 141, 11, 125, 116, 9, 203, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240})
+	remote := NewAccount()
+	message := NewMessage(feePayer.PublicKey, []Instruction{
+		{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer.PublicKey, IsSigner: true, IsWritable: true},
+				{PubKey: remote.PublicKey, IsSigner: true, IsWritable: true},
+			},
+			Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+		},
+	}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+
+	want, err := NewTransaction(message, []Account{feePayer, remote})
+	assert.Nil(t, err)
+
+	got, err := NewTransactionWithSigners(context.Background(), message, []Signer{feePayer, remoteSigner{account: remote}})
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+
+	raw, err := CreateRawTransactionWithSigners(context.Background(), CreateRawTransactionWithSignersParam{
+		Instructions: message.DecompileInstructions(),
+		// a signer passed twice is signed once
+		Signers:         []Signer{feePayer, remoteSigner{account: remote}, feePayer},
+		FeePayer:        feePayer.PublicKey,
+		RecentBlockHash: message.RecentBlockHash,
+	})
+	assert.Nil(t, err)
+	wantRaw, err := want.Serialize()
+	assert.Nil(t, err)
+	assert.Equal(t, wantRaw, raw)
+
+	errSigner := errors.New("signer unavailable")
+	_, err = NewTransactionWithSigners(context.Background(), message, []Signer{feePayer, remoteSigner{account: remote, err: errSigner}})
+	assert.True(t, errors.Is(err, errSigner))
+}
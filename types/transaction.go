@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"crypto/ed25519"
 	"encoding/binary"
 	"errors"
@@ -22,6 +23,11 @@ type Transaction struct {
 }
 
 func NewTransaction(message Message, signers []Account) (Transaction, error) {
+	return NewTransactionWithSigners(context.Background(), message, AccountSigners(signers))
+}
+
+// NewTransactionWithSigners creates a transaction signed by signers, the signatures of missing signers are left zero
+func NewTransactionWithSigners(ctx context.Context, message Message, signers []Signer) (Transaction, error) {
 	signatures := make([]Signature, 0, message.Header.NumRequireSignatures)
 	for i := uint8(0); i < message.Header.NumRequireSignatures; i++ {
 		signatures = append(signatures, make([]byte, 64))
@@ -37,11 +43,15 @@ func NewTransaction(message Message, signers []Account) (Transaction, error) {
 		return Transaction{}, fmt.Errorf("failed to serialize message, err: %v", err)
 	}
 	for _, signer := range signers {
-		idx, ok := m[signer.PublicKey]
+		idx, ok := m[signer.PubKey()]
 		if !ok {
-			return Transaction{}, fmt.Errorf("%w, %v is not a signer", ErrTransactionAddNotNecessarySignatures, signer.PublicKey)
+			return Transaction{}, fmt.Errorf("%w, %v is not a signer", ErrTransactionAddNotNecessarySignatures, signer.PubKey())
+		}
+		sig, err := signMessage(ctx, signer, data)
+		if err != nil {
+			return Transaction{}, err
 		}
-		signatures[idx] = signer.Sign(data)
+		signatures[idx] = sig
 	}
 
 	return Transaction{
@@ -65,8 +75,13 @@ func (tx *Transaction) AddSignature(sig []byte) error {
 	return fmt.Errorf("%w, no match signer", ErrTransactionAddNotNecessarySignatures)
 }
 
-func (tx *Transaction) sign(accounts []Account) (*Transaction, error) {
-	if int(tx.Message.Header.NumRequireSignatures) != len(accounts) {
+func (tx *Transaction) sign(ctx context.Context, signers []Signer) (*Transaction, error) {
+	// the same signer may be passed more than once
+	signerMap := map[common.PublicKey]Signer{}
+	for _, signer := range signers {
+		signerMap[signer.PubKey()] = signer
+	}
+	if int(tx.Message.Header.NumRequireSignatures) != len(signerMap) {
 		return nil, errors.New("signer's num not match")
 	}
 
@@ -75,36 +90,57 @@ func (tx *Transaction) sign(accounts []Account) (*Transaction, error) {
 		return nil, err
 	}
 
-	accountMap := map[common.PublicKey]ed25519.PrivateKey{}
-	for _, account := range accounts {
-		accountMap[account.PublicKey] = account.PrivateKey
-	}
-
 	for i := 0; i < int(tx.Message.Header.NumRequireSignatures); i++ {
-		privateKey, exist := accountMap[tx.Message.Accounts[i]]
+		signer, exist := signerMap[tx.Message.Accounts[i]]
 		if !exist {
 			return nil, fmt.Errorf("lack %s's private key", tx.Message.Accounts[i].ToBase58())
 		}
-		signature := ed25519.Sign(privateKey, message)
+		signature, err := signMessage(ctx, signer, message)
+		if err != nil {
+			return nil, err
+		}
 		tx.Signatures = append(tx.Signatures, signature)
 	}
 	return tx, nil
 }
 
+func signMessage(ctx context.Context, signer Signer, message []byte) (Signature, error) {
+	sig, err := signer.SignMessage(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign by %v, err: %w", signer.PubKey(), err)
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("invalid signature length from %v, expected: %v, got: %v", signer.PubKey(), ed25519.SignatureSize, len(sig))
+	}
+	return sig, nil
+}
+
+// Serialize encodes the transaction in wire format. A partially signed transaction is accepted, missing
+// signatures, i.e. no signatures at all or empty ones, are written as zero placeholders.
 func (tx *Transaction) Serialize() ([]byte, error) {
-	if len(tx.Signatures) == 0 || len(tx.Signatures) != int(tx.Message.Header.NumRequireSignatures) {
+	signatures := tx.Signatures
+	if len(signatures) == 0 {
+		signatures = make([]Signature, tx.Message.Header.NumRequireSignatures)
+	}
+	if len(signatures) == 0 || len(signatures) != int(tx.Message.Header.NumRequireSignatures) {
 		return nil, errors.New("Signature verification failed")
 	}
 
-	signatureCount := bincode.UintToVarLenBytes(uint64(len(tx.Signatures)))
+	signatureCount := bincode.UintToVarLenBytes(uint64(len(signatures)))
 	messageData, err := tx.Message.Serialize()
 	if err != nil {
 		return nil, err
 	}
 
-	output := make([]byte, 0, len(signatureCount)+len(signatureCount)*64+len(messageData))
+	output := make([]byte, 0, len(signatureCount)+len(signatures)*64+len(messageData))
 	output = append(output, signatureCount...)
-	for _, sig := range tx.Signatures {
+	for _, sig := range signatures {
+		if len(sig) == 0 {
+			sig = emptySignature
+		}
+		if len(sig) != 64 {
+			return nil, fmt.Errorf("invalid signature length, expected: 64, got: %v", len(sig))
+		}
 		output = append(output, sig...)
 	}
 	output = append(output, messageData...)
@@ -160,6 +196,23 @@ type CreateRawTransactionParam struct {
 }
 
 func CreateRawTransaction(param CreateRawTransactionParam) ([]byte, error) {
+	return CreateRawTransactionWithSigners(context.Background(), CreateRawTransactionWithSignersParam{
+		Instructions:    param.Instructions,
+		Signers:         AccountSigners(param.Signers),
+		FeePayer:        param.FeePayer,
+		RecentBlockHash: param.RecentBlockHash,
+	})
+}
+
+type CreateRawTransactionWithSignersParam struct {
+	Instructions    []Instruction
+	Signers         []Signer
+	FeePayer        common.PublicKey
+	RecentBlockHash string
+}
+
+// CreateRawTransactionWithSigners is CreateRawTransaction with signers which may keep their private keys elsewhere
+func CreateRawTransactionWithSigners(ctx context.Context, param CreateRawTransactionWithSignersParam) ([]byte, error) {
 	if param.RecentBlockHash == "" {
 		return nil, errors.New("recent block hash is required")
 	}
@@ -172,7 +225,7 @@ func CreateRawTransaction(param CreateRawTransactionParam) ([]byte, error) {
 		Message:    NewMessage(param.FeePayer, param.Instructions, param.RecentBlockHash),
 	}
 
-	signTx, err := tx.sign(param.Signers)
+	signTx, err := tx.sign(ctx, param.Signers)
 	if err != nil {
 		return nil, err
 	}
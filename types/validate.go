@@ -0,0 +1,129 @@
+package types
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/36625090/solana-go/common"
+)
+
+// PacketDataSize is the max size of a serialized transaction
+const PacketDataSize = 1232
+
+var (
+	ErrTransactionInvalidHeader           = errors.New("invalid message header")
+	ErrTransactionAccountIndexOutOfBounds = errors.New("account index out of bounds")
+	ErrTransactionDuplicateAccount        = errors.New("duplicate account")
+	ErrTransactionTooLarge                = errors.New("transaction too large")
+	ErrTransactionInvalidFeePayer         = errors.New("fee payer must be a writable signer")
+	ErrTransactionSignatureCountMismatch  = errors.New("signature count mismatch")
+	ErrTransactionInvalidSignature        = errors.New("invalid signature")
+)
+
+// SignatureVerificationError lists the signers whose signature is missing or invalid,
+// it matches ErrTransactionInvalidSignature with errors.Is
+type SignatureVerificationError struct {
+	Missing []common.PublicKey
+	Invalid []common.PublicKey
+}
+
+func (e *SignatureVerificationError) Error() string {
+	return fmt.Sprintf("%v, missing: %v, invalid: %v", ErrTransactionInvalidSignature, e.Missing, e.Invalid)
+}
+
+func (e *SignatureVerificationError) Unwrap() error {
+	return ErrTransactionInvalidSignature
+}
+
+// VerifySignatures checks that every required signer has a valid signature of the message,
+// it returns a *SignatureVerificationError otherwise
+func (tx Transaction) VerifySignatures() error {
+	if len(tx.Signatures) != int(tx.Message.Header.NumRequireSignatures) {
+		return fmt.Errorf("%w, expected: %v, got: %v", ErrTransactionSignatureCountMismatch, tx.Message.Header.NumRequireSignatures, len(tx.Signatures))
+	}
+	if len(tx.Message.Accounts) < len(tx.Signatures) {
+		return fmt.Errorf("%w, %v signers but %v accounts", ErrTransactionInvalidHeader, len(tx.Signatures), len(tx.Message.Accounts))
+	}
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+
+	var verifyErr SignatureVerificationError
+	for i, sig := range tx.Signatures {
+		signer := tx.Message.Accounts[i]
+		switch {
+		case isEmptySignature(sig):
+			verifyErr.Missing = append(verifyErr.Missing, signer)
+		case len(sig) != ed25519.SignatureSize || !ed25519.Verify(signer.Bytes(), data, sig):
+			verifyErr.Invalid = append(verifyErr.Invalid, signer)
+		}
+	}
+	if len(verifyErr.Missing) > 0 || len(verifyErr.Invalid) > 0 {
+		return &verifyErr
+	}
+	return nil
+}
+
+// Validate runs the sanity checks a node does before it accepts the transaction: the header is consistent
+// with the accounts, the fee payer is a writable signer, no account is listed twice, every instruction
+// index points to an account, and the serialized size fits in a packet. Signatures may be missing,
+// use VerifySignatures to check them.
+func (tx Transaction) Validate() error {
+	if len(tx.Signatures) != 0 && len(tx.Signatures) != int(tx.Message.Header.NumRequireSignatures) {
+		return fmt.Errorf("%w, expected: %v, got: %v", ErrTransactionSignatureCountMismatch, tx.Message.Header.NumRequireSignatures, len(tx.Signatures))
+	}
+	if err := tx.Message.Validate(); err != nil {
+		return err
+	}
+
+	data, err := tx.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize tx, err: %v", err)
+	}
+	if len(data) > PacketDataSize {
+		return fmt.Errorf("%w, max: %v, got: %v", ErrTransactionTooLarge, PacketDataSize, len(data))
+	}
+	return nil
+}
+
+// Validate checks the header, the account list and the instruction indexes of the message
+func (m Message) Validate() error {
+	header := m.Header
+	if header.NumRequireSignatures == 0 {
+		return ErrTransactionInvalidFeePayer
+	}
+	if header.NumReadonlySignedAccounts >= header.NumRequireSignatures {
+		return ErrTransactionInvalidFeePayer
+	}
+	if int(header.NumRequireSignatures)+int(header.NumReadonlyUnsignedAccounts) > len(m.Accounts) {
+		return fmt.Errorf("%w, %v signers and %v readonly unsigned accounts but %v accounts",
+			ErrTransactionInvalidHeader, header.NumRequireSignatures, header.NumReadonlyUnsignedAccounts, len(m.Accounts))
+	}
+
+	seen := make(map[common.PublicKey]struct{}, len(m.Accounts))
+	for _, account := range m.Accounts {
+		if _, ok := seen[account]; ok {
+			return fmt.Errorf("%w, %v", ErrTransactionDuplicateAccount, account)
+		}
+		seen[account] = struct{}{}
+	}
+
+	numAccounts := len(m.Accounts)
+	for _, table := range m.AddressLookupTables {
+		numAccounts += len(table.WritableIndexes) + len(table.ReadonlyIndexes)
+	}
+	for i, instruction := range m.Instructions {
+		// the fee payer is not a program and a program is not loaded from a lookup table
+		if instruction.ProgramIDIndex <= 0 || instruction.ProgramIDIndex >= len(m.Accounts) {
+			return fmt.Errorf("%w, instruction %v program id index %v", ErrTransactionAccountIndexOutOfBounds, i, instruction.ProgramIDIndex)
+		}
+		for _, idx := range instruction.Accounts {
+			if idx < 0 || idx >= numAccounts {
+				return fmt.Errorf("%w, instruction %v account index %v", ErrTransactionAccountIndexOutOfBounds, i, idx)
+			}
+		}
+	}
+	return nil
+}
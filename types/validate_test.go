@@ -0,0 +1,122 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/36625090/solana-go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_VerifySignatures(t *testing.T) {
+	feePayer := NewAccount()
+	alice := NewAccount()
+	message := NewMessage(feePayer.PublicKey, []Instruction{
+		{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer.PublicKey, IsSigner: true, IsWritable: true},
+				{PubKey: alice.PublicKey, IsSigner: true, IsWritable: true},
+			},
+			Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+		},
+	}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+
+	tx, err := NewTransaction(message, []Account{feePayer, alice})
+	assert.Nil(t, err)
+	assert.Nil(t, tx.VerifySignatures())
+	assert.Nil(t, tx.Validate())
+
+	tx, err = NewTransaction(message, []Account{feePayer})
+	assert.Nil(t, err)
+	tx.Signatures[0] = alice.Sign([]byte("other"))
+	err = tx.VerifySignatures()
+	assert.True(t, errors.Is(err, ErrTransactionInvalidSignature))
+	var verifyErr *SignatureVerificationError
+	assert.True(t, errors.As(err, &verifyErr))
+	assert.Equal(t, []common.PublicKey{feePayer.PublicKey}, verifyErr.Invalid)
+	assert.Equal(t, []common.PublicKey{alice.PublicKey}, verifyErr.Missing)
+	// signatures are not checked
+	assert.Nil(t, tx.Validate())
+
+	tx.Signatures = tx.Signatures[:1]
+	assert.True(t, errors.Is(tx.VerifySignatures(), ErrTransactionSignatureCountMismatch))
+	assert.True(t, errors.Is(tx.Validate(), ErrTransactionSignatureCountMismatch))
+}
+
+func TestMessage_Validate(t *testing.T) {
+	feePayer := NewAccount().PublicKey
+	to := NewAccount().PublicKey
+	valid := func() Message {
+		return NewMessage(feePayer, []Instruction{
+			{
+				ProgramID: common.SystemProgramID,
+				Accounts: []AccountMeta{
+					{PubKey: feePayer, IsSigner: true, IsWritable: true},
+					{PubKey: to, IsSigner: false, IsWritable: true},
+				},
+				Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+			},
+		}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+	}
+
+	tests := []struct {
+		name   string
+		modify func(m *Message)
+		err    error
+	}{
+		{
+			name:   "valid",
+			modify: func(m *Message) {},
+		},
+		{
+			name:   "no signer",
+			modify: func(m *Message) { m.Header.NumRequireSignatures = 0 },
+			err:    ErrTransactionInvalidFeePayer,
+		},
+		{
+			name:   "readonly fee payer",
+			modify: func(m *Message) { m.Header.NumReadonlySignedAccounts = 1 },
+			err:    ErrTransactionInvalidFeePayer,
+		},
+		{
+			name:   "header exceeds accounts",
+			modify: func(m *Message) { m.Header.NumReadonlyUnsignedAccounts = 3 },
+			err:    ErrTransactionInvalidHeader,
+		},
+		{
+			name:   "duplicate account",
+			modify: func(m *Message) { m.Accounts[1] = feePayer },
+			err:    ErrTransactionDuplicateAccount,
+		},
+		{
+			name:   "account index out of bounds",
+			modify: func(m *Message) { m.Instructions[0].Accounts[1] = 3 },
+			err:    ErrTransactionAccountIndexOutOfBounds,
+		},
+		{
+			name:   "fee payer as program",
+			modify: func(m *Message) { m.Instructions[0].ProgramIDIndex = 0 },
+			err:    ErrTransactionAccountIndexOutOfBounds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid()
+			tt.modify(&m)
+			assert.ErrorIs(t, m.Validate(), tt.err)
+		})
+	}
+}
+
+func TestTransaction_ValidateTooLarge(t *testing.T) {
+	feePayer := NewAccount()
+	tx, err := NewTransaction(NewMessage(feePayer.PublicKey, []Instruction{
+		{
+			ProgramID: common.PublicKeyFromString("CustomProgram111111111111111111111111111111"),
+			Data:      make([]byte, PacketDataSize),
+		},
+	}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5"), []Account{feePayer})
+	assert.Nil(t, err)
+	assert.ErrorIs(t, tx.Validate(), ErrTransactionTooLarge)
+}